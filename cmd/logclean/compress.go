@@ -0,0 +1,114 @@
+package main
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/useful-go/pkg/common"
+	"github.com/useful-go/pkg/fs"
+)
+
+// 이미 압축된 파일 확장자 (--compress에서 건너뜀)
+var compressedExts = map[string]bool{
+	".gz": true, ".tgz": true, ".bz2": true, ".xz": true, ".zst": true, ".lz4": true,
+	".zip": true, ".7z": true, ".lzma": true, ".z": true,
+}
+
+// errNotSmaller 압축해도 줄지 않아 원본을 그대로 둔 경우
+var errNotSmaller = errors.New("압축해도 크기가 줄지 않음")
+
+func isCompressed(name string) bool {
+	return compressedExts[strings.ToLower(filepath.Ext(name))]
+}
+
+// compressTarget 분석 단계에서 고른 파일을 gzip으로 압축하고 압축한 파일 수와 압축 전/후 크기를 반환합니다.
+func compressTarget(result CleanResult) (count int, before, after int64) {
+	for _, f := range result.selected {
+		size, err := gzipFile(f.path, f.info)
+		if errors.Is(err, errNotSmaller) {
+			continue
+		}
+		if err != nil {
+			common.Warning("%s: %v", f.path, err)
+			continue
+		}
+		count++
+		before += f.info.Size()
+		after += size
+	}
+
+	if count > 0 {
+		common.Success("%s: %d개 파일 압축 %s → %s", result.Target.Description, count, fs.FormatSize(before), fs.FormatSize(after))
+	}
+	return count, before, after
+}
+
+// gzipFile path를 path.gz로 압축하고 원본을 지웁니다. 권한, 소유자, 수정 시각을 유지합니다.
+// 압축 결과가 원본보다 작지 않으면 .gz를 버리고 원본을 남깁니다 (errNotSmaller).
+func gzipFile(path string, info os.FileInfo) (int64, error) {
+	dst := path + ".gz"
+	if _, err := os.Lstat(dst); err == nil {
+		return 0, fmt.Errorf("%s가 이미 있어 건너뜀", filepath.Base(dst))
+	}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	// 중간에 실패해도 반쯤 쓴 .gz가 남지 않도록 임시 파일에 쓴 뒤 이름을 바꿉니다.
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.gz")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	zw, err := gzip.NewWriterLevel(tmp, gzip.BestCompression)
+	if err != nil {
+		tmp.Close()
+		return 0, err
+	}
+	zw.Name = info.Name()
+	zw.ModTime = info.ModTime()
+	if _, err := io.Copy(zw, src); err != nil {
+		tmp.Close()
+		return 0, err
+	}
+	if err := zw.Close(); err != nil {
+		tmp.Close()
+		return 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, err
+	}
+	if tmpInfo, err := os.Stat(tmp.Name()); err == nil && tmpInfo.Size() >= info.Size() {
+		return 0, errNotSmaller
+	}
+
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return 0, err
+	}
+	copyOwner(tmp.Name(), info)
+	if err := os.Chtimes(tmp.Name(), info.ModTime(), info.ModTime()); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return 0, err
+	}
+	if err := os.Remove(path); err != nil {
+		os.Remove(dst)
+		return 0, err
+	}
+
+	compressed, err := os.Stat(dst)
+	if err != nil {
+		return 0, err
+	}
+	return compressed.Size(), nil
+}
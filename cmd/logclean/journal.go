@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/useful-go/pkg/common"
+	"github.com/useful-go/pkg/fs"
+)
+
+// journald 대상의 Kind
+const kindJournald = "journald"
+
+// 영구/휘발성 저널 디렉토리
+var journalDirs = []string{"/var/log/journal", "/run/log/journal"}
+
+// "Archived and active journals take up 1.2G in the file system."
+var journalUsagePattern = regexp.MustCompile(`take up ([0-9.]+[KMGTP]?)B?`)
+
+// journalFile 보관(archived) 저널 파일. 활성 파일(system.journal)은 vacuum 대상이 아닙니다.
+type journalFile struct {
+	path string
+	info os.FileInfo
+}
+
+// analyzeJournal 현재 사용량을 조회하고, vacuum이 지울 보관 파일을 추정합니다.
+// --vacuum-time은 cutoff 이전 보관 파일을, --vacuum-size는 사용량이 한도 아래가 될 때까지 오래된 보관 파일을 지웁니다.
+func analyzeJournal(target CleanTarget, policy Policy) CleanResult {
+	result := CleanResult{Target: target}
+	if _, err := exec.LookPath("journalctl"); err != nil {
+		result.Error = fmt.Errorf("journalctl 없음")
+		return result
+	}
+
+	usage, err := journalUsage()
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	result.Usage = usage
+
+	// 한도 계산은 journalctl 사용량과 실제 파일 크기 중 큰 값 기준
+	remaining := usage
+	var dirTotal int64
+	for _, dir := range journalDirs {
+		dirTotal += fs.GetDirSize(dir)
+	}
+	if dirTotal > remaining {
+		remaining = dirTotal
+	}
+	for _, f := range archivedJournals() {
+		expired := policy.UseAge && f.info.ModTime().Before(policy.Cutoff)
+		overQuota := policy.JournalSize > 0 && remaining > policy.JournalSize
+		if !expired && !overQuota {
+			continue
+		}
+		result.FilesCount++
+		result.TotalSize += f.info.Size()
+		remaining -= f.info.Size()
+	}
+	return result
+}
+
+// cleanJournal journalctl --vacuum-time/--vacuum-size를 실행하고 줄어든 사용량을 반환합니다.
+// 크기 한도만 주어 나이 기준을 쓰지 않으면 --vacuum-time은 빼고 실행합니다.
+func cleanJournal(target CleanTarget, policy Policy) int64 {
+	var args []string
+	if policy.UseAge {
+		args = append(args, fmt.Sprintf("--vacuum-time=%dd", policy.Days))
+	}
+	if policy.JournalSize > 0 {
+		args = append(args, fmt.Sprintf("--vacuum-size=%d", policy.JournalSize))
+	}
+	if len(args) == 0 {
+		return 0
+	}
+
+	before, _ := journalUsage()
+	if out, err := exec.Command("journalctl", args...).CombinedOutput(); err != nil {
+		common.Error("%s: journalctl %s 실패: %s", target.Description, strings.Join(args, " "), strings.TrimSpace(string(out)))
+		return 0
+	}
+
+	after, err := journalUsage()
+	if err != nil || after >= before {
+		return 0
+	}
+	deleted := before - after
+	common.Success("%s: %s 삭제됨 (%s 남음)", target.Description, fs.FormatSize(deleted), fs.FormatSize(after))
+	return deleted
+}
+
+// journalUsage journalctl --disk-usage로 저널 전체 사용량을 조회합니다.
+func journalUsage() (int64, error) {
+	out, err := exec.Command("journalctl", "--disk-usage").CombinedOutput()
+	if err != nil {
+		return 0, fmt.Errorf("journalctl --disk-usage 실패: %s", strings.TrimSpace(string(out)))
+	}
+	m := journalUsagePattern.FindStringSubmatch(string(out))
+	if m == nil {
+		return 0, fmt.Errorf("journalctl --disk-usage 출력을 해석할 수 없음: %s", strings.TrimSpace(string(out)))
+	}
+	return fs.ParseSize(m[1])
+}
+
+// archivedJournals 보관 저널 파일을 오래된 순서로 반환합니다 (이름에 @가 있는 *.journal, *.journal~).
+func archivedJournals() []journalFile {
+	var files []journalFile
+	for _, dir := range journalDirs {
+		filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return nil
+			}
+			name := info.Name()
+			if strings.Contains(name, "@") && (strings.HasSuffix(name, ".journal") || strings.HasSuffix(name, ".journal~")) {
+				files = append(files, journalFile{path: path, info: info})
+			}
+			return nil
+		})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].info.ModTime().Before(files[j].info.ModTime())
+	})
+	return files
+}
+
+func formatJournalPolicy(policy Policy) string {
+	var parts []string
+	if policy.UseAge {
+		parts = append(parts, "vacuum-time="+strconv.Itoa(policy.Days)+"d")
+	}
+	if policy.JournalSize > 0 {
+		parts = append(parts, "vacuum-size="+fs.FormatSize(policy.JournalSize))
+	}
+	if len(parts) == 0 {
+		return "정리 기준 없음, --days 또는 --journal-size 필요"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -5,26 +5,34 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"time"
 
 	"github.com/useful-go/pkg/common"
+	"github.com/useful-go/pkg/config"
 	"github.com/useful-go/pkg/fs"
 	"github.com/useful-go/pkg/ui"
 )
 
-var cleanTargets = []CleanTarget{
-	{Path: "~/Library/Logs", Description: "시스템 로그"},
-	{Path: "~/Library/Caches", Description: "앱 캐시"},
-	{Path: "/private/var/log", Description: "시스템 var 로그", NeedsSudo: true},
-	{Path: "~/.Trash", Description: "휴지통"},
-	{Path: "~/Library/Application Support/CrashReporter", Description: "크래시 리포트"},
-	{Path: "/Library/Logs", Description: "라이브러리 로그", NeedsSudo: true},
+type CleanTarget struct {
+	Path        string   `json:"path"` // ~와 glob(*) 사용 가능
+	Description string   `json:"description"`
+	NeedsSudo   bool     `json:"needs_sudo"`
+	Patterns    []string `json:"patterns,omitempty"` // 파일 이름 패턴 (비어 있으면 모든 파일)
+	Kind        string   `json:"kind,omitempty"`     // 빈 값: 파일, "journald": journalctl vacuum
+	Compress    bool     `json:"compress,omitempty"` // --compress로 압축할 로그 대상 (휴지통, 캐시, 크래시 리포트는 제외)
 }
 
-type CleanTarget struct {
-	Path        string
-	Description string
-	NeedsSudo   bool
+// Policy 정리 기준
+type Policy struct {
+	Days        int
+	Cutoff      time.Time // Days일 전
+	UseAge      bool      // 나이 기준 적용 여부 (크기 한도만 주면 false)
+	MaxSize     int64     // 대상별 크기 한도 (0이면 제한 없음)
+	MaxTotal    int64     // 전체 파일 대상 크기 한도
+	KeepLast    int       // 디렉토리마다 항상 남길 최근 파일 수
+	JournalSize int64     // 저널 크기 한도 (0이면 제한 없음)
+	Compress    bool      // 삭제 대신 gzip 압축
 }
 
 type CleanResult struct {
@@ -32,139 +40,278 @@ type CleanResult struct {
 	FilesCount  int
 	TotalSize   int64
 	DeletedSize int64
+	AfterSize   int64 // --compress 후 크기
+	Usage       int64 // 현재 전체 사용량 (journald)
 	Error       error
+
+	selected  []fileEntry // 정리할 파일
+	remaining []fileEntry // 남길 후보 파일 (크기 한도 계산용)
+}
+
+// tally 선택된 파일로 FilesCount, TotalSize를 다시 계산합니다.
+func (r *CleanResult) tally() {
+	r.FilesCount = len(r.selected)
+	r.TotalSize = sumSize(r.selected)
 }
 
 func main() {
 	dryRun := flag.Bool("dry-run", false, "삭제하지 않고 정리 대상만 표시")
 	days := flag.Int("days", 7, "N일 이상 된 파일만 정리")
 	all := flag.Bool("all", false, "모든 대상 정리 (sudo 필요한 항목 포함)")
+	compress := flag.Bool("compress", false, "삭제하지 않고 gzip으로 압축 (수정 시각/권한 유지)")
+	journalSize := flag.String("journal-size", "", "systemd 저널 크기 한도 (예: 500M, 1G)")
+	maxSize := flag.String("max-size", "", "대상별 크기 한도, 넘으면 오래된 파일부터 정리 (예: 2GB)")
+	maxTotal := flag.String("max-total", "", "전체 파일 대상 크기 한도 (예: 10GB)")
+	keepLast := flag.Int("keep-last", 0, "디렉토리마다 항상 남길 최근 파일 수")
 	flag.Parse()
 
-	common.Header("🧹 macOS 로그/캐시 클리너")
+	policy := Policy{
+		Days:     *days,
+		Cutoff:   time.Now().AddDate(0, 0, -*days),
+		UseAge:   true,
+		KeepLast: *keepLast,
+		Compress: *compress,
+	}
+	for _, opt := range []struct {
+		value string
+		dst   *int64
+	}{{*journalSize, &policy.JournalSize}, {*maxSize, &policy.MaxSize}, {*maxTotal, &policy.MaxTotal}} {
+		if opt.value == "" {
+			continue
+		}
+		size, err := fs.ParseSize(opt.value)
+		if err != nil {
+			common.Fatal("%v", err)
+		}
+		*opt.dst = size
+	}
+
+	// 한도 초과분은 최근 파일(기록 중인 로그 포함)까지 고를 수 있고, 압축으로는 한도를 맞출 수 없음
+	if policy.Compress && (policy.MaxSize > 0 || policy.MaxTotal > 0) {
+		common.Fatal("--compress는 --max-size, --max-total과 함께 사용할 수 없습니다 (--days로 압축할 파일을 고르세요)")
+	}
+
+	// 크기 한도만 주면 나이 기준은 적용하지 않음 (--days를 함께 주면 둘 다 적용)
+	if policy.MaxSize > 0 || policy.MaxTotal > 0 {
+		policy.UseAge = false
+		flag.Visit(func(f *flag.Flag) {
+			if f.Name == "days" {
+				policy.UseAge = true
+			}
+		})
+	}
+
+	common.Header("🧹 로그/캐시 클리너 (%s)", runtime.GOOS)
 	fmt.Println()
 
+	cleanTargets, err := loadTargets()
+	if err != nil {
+		common.Fatal("%v", err)
+	}
+	if len(cleanTargets) == 0 {
+		common.Warning("%s에서 사용할 정리 대상이 없습니다 (%s에 targets를 추가하세요)", runtime.GOOS, config.Path("logclean"))
+		return
+	}
+
 	if *dryRun {
 		common.Info("Dry-run 모드: 실제 삭제 없이 분석만 수행합니다")
 		fmt.Println()
 	}
 
 	var results []CleanResult
-	cutoffTime := time.Now().AddDate(0, 0, -*days)
 
 	for _, target := range cleanTargets {
 		if target.NeedsSudo && !*all {
 			continue
 		}
+		// 로그 대상만 압축 (휴지통 메타데이터, 캐시, 크래시 리포트는 압축하면 안 되고 저널은 journald가 이미 압축)
+		if policy.Compress && !target.Compress {
+			continue
+		}
 
-		result := analyzeTarget(target, cutoffTime)
+		result := analyzeTarget(target, policy)
 		results = append(results, result)
 	}
 
-	printSummary(results)
+	if policy.MaxTotal > 0 {
+		applyTotalQuota(results, policy.MaxTotal)
+	}
+
+	printSummary("분석 결과:", results, policy)
 
 	if *dryRun {
 		common.Info("실제 삭제를 원하면 --dry-run 플래그 없이 실행하세요")
 		return
 	}
 
-	confirm := ui.YesNoConfirmation("\n정리를 진행하시겠습니까?")
+	question := "\n정리를 진행하시겠습니까?"
+	if policy.Compress {
+		question = "\n압축을 진행하시겠습니까?"
+	}
+	confirm := ui.YesNoConfirmation(question)
 	if !confirm.MustConfirm() {
 		return
 	}
 
 	var totalDeleted int64
-	for _, result := range results {
-		if result.Error != nil || result.FilesCount == 0 {
+	for i := range results {
+		r := &results[i]
+		if r.Error != nil || r.FilesCount == 0 {
 			continue
 		}
-		deleted := cleanTarget(result.Target, cutoffTime)
-		totalDeleted += deleted
+		if policy.Compress {
+			count, before, after := compressTarget(*r)
+			r.FilesCount, r.TotalSize, r.AfterSize, r.DeletedSize = count, before, after, before-after
+		} else {
+			r.DeletedSize = cleanTarget(*r, policy)
+		}
+		totalDeleted += r.DeletedSize
 	}
 
+	if policy.Compress {
+		fmt.Println()
+		printSummary("압축 결과:", results, policy)
+		common.Success("총 %s 절약", fs.FormatSize(totalDeleted))
+		return
+	}
 	common.Success("총 %s 정리 완료", fs.FormatSize(totalDeleted))
 }
 
-func analyzeTarget(target CleanTarget, cutoff time.Time) CleanResult {
+func analyzeTarget(target CleanTarget, policy Policy) CleanResult {
+	if target.Kind == kindJournald {
+		return analyzeJournal(target, policy)
+	}
 	result := CleanResult{Target: target}
-	path := expandPath(target.Path)
 
-	info, err := os.Stat(path)
+	dirs, err := targetDirs(target)
 	if err != nil {
 		result.Error = err
 		return result
 	}
 
-	if !info.IsDir() {
-		result.Error = fmt.Errorf("디렉토리가 아님")
-		return result
-	}
-
-	filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil
-		}
-		if info.IsDir() {
-			return nil
+	var files []fileEntry
+	walkTarget(target, dirs, func(filePath string, info os.FileInfo) {
+		if policy.Compress && isCompressed(info.Name()) {
+			return
 		}
-		if info.ModTime().Before(cutoff) {
-			result.FilesCount++
-			result.TotalSize += info.Size()
-		}
-		return nil
+		files = append(files, fileEntry{path: filePath, info: info})
 	})
 
+	result.selected, result.remaining = selectFiles(files, policy)
+	result.tally()
 	return result
 }
 
-func cleanTarget(target CleanTarget, cutoff time.Time) int64 {
+// cleanTarget 분석 단계에서 고른 파일을 삭제합니다.
+func cleanTarget(result CleanResult, policy Policy) int64 {
+	if result.Target.Kind == kindJournald {
+		return cleanJournal(result.Target, policy)
+	}
 	var deleted int64
-	path := expandPath(target.Path)
 
-	filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil
-		}
-		if info.IsDir() {
-			return nil
+	for _, f := range result.selected {
+		if err := os.Remove(f.path); err == nil {
+			deleted += f.info.Size()
 		}
-		if info.ModTime().Before(cutoff) {
-			if err := os.Remove(filePath); err == nil {
-				deleted += info.Size()
-			}
-		}
-		return nil
-	})
+	}
 
 	if deleted > 0 {
-		common.Success("%s: %s 삭제됨", target.Description, fs.FormatSize(deleted))
+		common.Success("%s: %s 삭제됨", result.Target.Description, fs.FormatSize(deleted))
 	}
 	return deleted
 }
 
-func printSummary(results []CleanResult) {
-	common.Header("분석 결과:")
+// printSummary 대상별 정리 가능 크기를 보여줍니다. --compress로 압축한 뒤에는 압축 전 → 후 크기를 보여줍니다.
+func printSummary(title string, results []CleanResult, policy Policy) {
+	common.Header(title)
 	fmt.Println()
 
 	var totalFiles int
-	var totalSize int64
+	var totalSize, totalAfter int64
 
 	for _, r := range results {
 		if r.Error != nil {
 			common.Warning("%-20s: 접근 불가 (%v)", r.Target.Description, r.Error)
 			continue
 		}
+		note := ""
+		if r.Target.Kind == kindJournald {
+			note = fmt.Sprintf(" (현재 %s, %s)", fs.FormatSize(r.Usage), formatJournalPolicy(policy))
+		}
 		if r.FilesCount == 0 {
-			fmt.Printf("  %-20s: 정리 대상 없음\n", r.Target.Description)
+			fmt.Printf("  %-20s: 정리 대상 없음%s\n", r.Target.Description, note)
 			continue
 		}
-		fmt.Printf("  %-20s: %d개 파일, %s\n", r.Target.Description, r.FilesCount, fs.FormatSize(r.TotalSize))
+		if r.AfterSize > 0 {
+			note = fmt.Sprintf(" → %s (%s 절약)", fs.FormatSize(r.AfterSize), fs.FormatSize(r.DeletedSize))
+		} else if r.Target.Kind == "" && (policy.MaxSize > 0 || policy.MaxTotal > 0) {
+			note = fmt.Sprintf(" (정리 후 %s 남음)", fs.FormatSize(sumSize(r.remaining)))
+		}
+		fmt.Printf("  %-20s: %d개 파일, %s%s\n", r.Target.Description, r.FilesCount, fs.FormatSize(r.TotalSize), note)
 		totalFiles += r.FilesCount
 		totalSize += r.TotalSize
+		totalAfter += r.AfterSize
 	}
 
 	fmt.Println()
-	common.Info("총 %d개 파일, %s 정리 가능", totalFiles, fs.FormatSize(totalSize))
+	switch {
+	case totalAfter > 0:
+		common.Info("총 %d개 파일, %s → %s", totalFiles, fs.FormatSize(totalSize), fs.FormatSize(totalAfter))
+	case policy.Compress:
+		common.Info("총 %d개 파일, %s 압축 가능", totalFiles, fs.FormatSize(totalSize))
+	default:
+		common.Info("총 %d개 파일, %s 정리 가능", totalFiles, fs.FormatSize(totalSize))
+	}
+}
+
+// targetDirs 대상 경로의 ~와 glob을 펼쳐 존재하는 디렉토리 목록을 반환합니다.
+func targetDirs(target CleanTarget) ([]string, error) {
+	matches, err := filepath.Glob(expandPath(target.Path))
+	if err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+	for _, m := range matches {
+		if info, err := os.Stat(m); err == nil && info.IsDir() {
+			dirs = append(dirs, m)
+		}
+	}
+	if len(dirs) == 0 {
+		if len(matches) > 0 {
+			return nil, fmt.Errorf("디렉토리가 아님")
+		}
+		return nil, fmt.Errorf("경로 없음")
+	}
+	return dirs, nil
+}
+
+// walkTarget 대상 디렉토리에서 파일 이름 패턴에 맞는 파일마다 fn을 호출합니다.
+func walkTarget(target CleanTarget, dirs []string, fn func(filePath string, info os.FileInfo)) {
+	for _, dir := range dirs {
+		filepath.Walk(dir, func(filePath string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+			if info.IsDir() || !matchPatterns(target.Patterns, info.Name()) {
+				return nil
+			}
+			fn(filePath, info)
+			return nil
+		})
+	}
+}
+
+func matchPatterns(patterns []string, name string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, p := range patterns {
+		if ok, _ := filepath.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
 }
 
 func expandPath(path string) string {
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// copyOwner 소유자 개념이 다른 OS에서는 아무것도 하지 않습니다.
+func copyOwner(path string, info os.FileInfo) {}
@@ -0,0 +1,15 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// copyOwner 원본 파일의 소유자/그룹을 path에 적용합니다. 권한이 없으면 무시합니다.
+func copyOwner(path string, info os.FileInfo) {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		os.Lchown(path, int(st.Uid), int(st.Gid))
+	}
+}
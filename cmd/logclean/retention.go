@@ -0,0 +1,126 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// fileEntry 정리 후보 파일
+type fileEntry struct {
+	path string
+	info os.FileInfo
+	keep bool // --keep-last로 보호되는 최근 파일
+}
+
+// selectFiles 한 대상의 후보 파일을 정리할 파일과 남길 파일로 나눕니다.
+// 1) 디렉토리마다 최근 KeepLast개는 항상 남기고, 2) 나이 기준(--days)에 걸린 파일을 고른 뒤,
+// 3) 남은 크기가 MaxSize를 넘으면 오래된 파일부터 더 고릅니다.
+func selectFiles(files []fileEntry, policy Policy) (selected, remaining []fileEntry) {
+	markKeepLast(files, policy.KeepLast)
+	sortOldestFirst(files)
+
+	for _, f := range files {
+		if !f.keep && policy.UseAge && f.info.ModTime().Before(policy.Cutoff) {
+			selected = append(selected, f)
+		} else {
+			remaining = append(remaining, f)
+		}
+	}
+
+	if policy.MaxSize > 0 {
+		over := sumSize(remaining) - policy.MaxSize
+		var kept []fileEntry
+		for _, f := range remaining {
+			if over > 0 && !f.keep {
+				selected = append(selected, f)
+				over -= f.info.Size()
+				continue
+			}
+			kept = append(kept, f)
+		}
+		remaining = kept
+	}
+	return selected, remaining
+}
+
+// applyTotalQuota 모든 파일 대상의 남은 크기 합이 MaxTotal 아래가 될 때까지 전체에서 오래된 파일부터 고릅니다.
+func applyTotalQuota(results []CleanResult, maxTotal int64) {
+	type candidate struct {
+		result int
+		file   fileEntry
+	}
+
+	var total int64
+	var candidates []candidate
+	for i, r := range results {
+		for _, f := range r.remaining {
+			total += f.info.Size()
+			if !f.keep {
+				candidates = append(candidates, candidate{i, f})
+			}
+		}
+	}
+	if total <= maxTotal {
+		return
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].file.info.ModTime().Before(candidates[j].file.info.ModTime())
+	})
+
+	moved := make(map[string]bool)
+	for _, c := range candidates {
+		if total <= maxTotal {
+			break
+		}
+		results[c.result].selected = append(results[c.result].selected, c.file)
+		moved[c.file.path] = true
+		total -= c.file.info.Size()
+	}
+
+	for i := range results {
+		var kept []fileEntry
+		for _, f := range results[i].remaining {
+			if !moved[f.path] {
+				kept = append(kept, f)
+			}
+		}
+		results[i].remaining = kept
+		results[i].tally()
+	}
+}
+
+// markKeepLast 디렉토리마다 가장 최근 파일 n개에 keep을 표시합니다.
+func markKeepLast(files []fileEntry, n int) {
+	if n <= 0 {
+		return
+	}
+	byDir := make(map[string][]int)
+	for i, f := range files {
+		dir := filepath.Dir(f.path)
+		byDir[dir] = append(byDir[dir], i)
+	}
+	for _, idx := range byDir {
+		sort.Slice(idx, func(a, b int) bool {
+			return files[idx[a]].info.ModTime().After(files[idx[b]].info.ModTime())
+		})
+		for k := 0; k < n && k < len(idx); k++ {
+			files[idx[k]].keep = true
+		}
+	}
+}
+
+func sortOldestFirst(files []fileEntry) {
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].info.ModTime().Before(files[j].info.ModTime())
+	})
+}
+
+func sumSize(files []fileEntry) int64 {
+	var size int64
+	for _, f := range files {
+		size += f.info.Size()
+	}
+	return size
+}
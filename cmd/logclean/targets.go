@@ -0,0 +1,71 @@
+package main
+
+import (
+	"runtime"
+
+	"github.com/useful-go/pkg/config"
+)
+
+// targetsByOS 운영체제별 기본 정리 대상 (runtime.GOOS로 선택)
+var targetsByOS = map[string][]CleanTarget{
+	"darwin": {
+		{Path: "~/Library/Logs", Description: "시스템 로그", Compress: true},
+		{Path: "~/Library/Caches", Description: "앱 캐시"},
+		{Path: "/private/var/log", Description: "시스템 var 로그", NeedsSudo: true, Compress: true},
+		{Path: "~/.Trash", Description: "휴지통"},
+		{Path: "~/Library/Application Support/CrashReporter", Description: "크래시 리포트"},
+		{Path: "/Library/Logs", Description: "라이브러리 로그", NeedsSudo: true, Compress: true},
+	},
+	"linux": {
+		{Path: "~/.cache", Description: "캐시 내 앱 로그", Patterns: []string{"*.log", "*.log.*"}, Compress: true},
+		{Path: "~/.local/share/*/logs", Description: "앱 데이터 로그", Compress: true},
+		{Path: "~/.local/share/Trash", Description: "휴지통"},
+		{Path: "/var/log", Description: "회전된 시스템 로그", Patterns: []string{"*.[0-9]", "*.gz", "*.old"}, NeedsSudo: true, Compress: true},
+		{Path: "/var/crash", Description: "크래시 리포트", NeedsSudo: true},
+		{Path: "/var/lib/systemd/coredump", Description: "코어 덤프", NeedsSudo: true},
+		{Description: "systemd 저널", Kind: kindJournald, NeedsSudo: true},
+	},
+}
+
+// targetConfig ~/.config/useful/logclean.json
+//
+//	{
+//	  "targets": [{"path": "~/work/*/logs", "description": "프로젝트 로그", "patterns": ["*.log"], "compress": true}],
+//	  "exclude": ["~/.local/share/Trash"],
+//	  "replace": false
+//	}
+//
+// targets는 기본 대상에 추가되며, replace가 true면 기본 대상 대신 사용합니다.
+// exclude는 경로 또는 설명이 일치하는 기본 대상을 뺍니다.
+type targetConfig struct {
+	Targets []CleanTarget `json:"targets"`
+	Exclude []string      `json:"exclude"`
+	Replace bool          `json:"replace"`
+}
+
+// loadTargets 현재 OS의 기본 대상에 머신별 설정을 적용합니다.
+func loadTargets() ([]CleanTarget, error) {
+	var cfg targetConfig
+	if err := config.Load("logclean", &cfg); err != nil {
+		return nil, err
+	}
+
+	var targets []CleanTarget
+	if !cfg.Replace {
+		for _, t := range targetsByOS[runtime.GOOS] {
+			if !excluded(t, cfg.Exclude) {
+				targets = append(targets, t)
+			}
+		}
+	}
+	return append(targets, cfg.Targets...), nil
+}
+
+func excluded(t CleanTarget, exclude []string) bool {
+	for _, e := range exclude {
+		if e == t.Path || e == t.Description {
+			return true
+		}
+	}
+	return false
+}
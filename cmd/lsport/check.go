@@ -0,0 +1,447 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/useful-go/pkg/common"
+)
+
+// DeclaredPort 프로젝트 설정 파일에 선언된 호스트 포트
+type DeclaredPort struct {
+	Port     int    `json:"port"`
+	Protocol string `json:"protocol"` // tcp, udp
+	Source   string `json:"source"`   // 파일:줄
+	Name     string `json:"name"`     // compose 서비스, 환경 변수, npm 스크립트 이름 등
+
+	// check 결과
+	Occupied bool   `json:"occupied"`
+	PID      string `json:"pid,omitempty"`
+	Command  string `json:"command,omitempty"`
+	Owner    string `json:"owner,omitempty"` // 컨테이너 이름 (Docker 게시 포트)
+}
+
+// 검사하지 않는 디렉토리
+var checkSkipDirs = map[string]bool{
+	".git": true, "node_modules": true, "vendor": true, "target": true,
+	"build": true, "dist": true, ".venv": true, "venv": true, ".gradle": true, ".idea": true,
+}
+
+var (
+	envPortLine  = regexp.MustCompile(`^\s*(?:export\s+)?([A-Za-z0-9_]*PORT)\s*=\s*["']?(\d+)["']?\s*(?:#.*)?$`)
+	envLine      = regexp.MustCompile(`^\s*(?:export\s+)?([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(.*)$`)
+	scriptPort   = regexp.MustCompile(`(?:--port[= ]|\s-p\s+|\bPORT=)(\d+)`)
+	composeVar   = regexp.MustCompile(`\$\{([A-Za-z0-9_]+)(?::?-([^}]*))?\}`)
+	springPort   = regexp.MustCompile(`^\s*server\.port\s*[:=]\s*(.+)$`)
+	springPlaced = regexp.MustCompile(`^\$\{[^:}]+:(\d+)\}$`)
+)
+
+// runCheck 프로젝트에 선언된 포트가 이미 사용 중인지 확인합니다. 충돌이 있으면 종료 코드 1입니다.
+func runCheck(args []string, services map[string]string, jsonOut bool) {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		common.Fatal("디렉토리를 찾을 수 없습니다: %s", dir)
+	}
+
+	declared := scanProject(dir)
+	conflicts := markOccupied(declared, getPortList(PortFilter{}, services))
+
+	if jsonOut {
+		printJSON(declared)
+	} else if len(declared) == 0 {
+		common.Warning("%s에서 선언된 포트를 찾지 못했습니다", dir)
+	} else {
+		printCheckTable(declared, conflicts)
+	}
+
+	if conflicts > 0 {
+		os.Exit(1)
+	}
+}
+
+// scanProject docker-compose, .env, package.json, Spring 설정에서 포트를 찾습니다.
+func scanProject(root string) []DeclaredPort {
+	declared := []DeclaredPort{}
+
+	var composeFiles []string
+	filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := info.Name()
+		if info.IsDir() {
+			if path != root && checkSkipDirs[name] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		switch {
+		case isComposeFile(name):
+			composeFiles = append(composeFiles, path)
+		case isEnvFile(name):
+			declared = append(declared, scanEnvFile(path)...)
+		case name == "package.json":
+			declared = append(declared, scanPackageJSON(path)...)
+		case isSpringConfig(name):
+			declared = append(declared, scanSpringConfig(path)...)
+		}
+		return nil
+	})
+
+	for _, path := range composeFiles {
+		// docker compose처럼 compose 파일과 같은 디렉토리의 .env만 치환에 사용
+		env := readEnvFile(filepath.Join(filepath.Dir(path), ".env"))
+		declared = append(declared, scanComposeFile(path, env)...)
+	}
+
+	// 검사한 디렉토리 기준 상대 경로로 표시
+	prefix := filepath.Clean(root) + string(os.PathSeparator)
+	for i := range declared {
+		declared[i].Source = strings.TrimPrefix(declared[i].Source, prefix)
+	}
+
+	sort.SliceStable(declared, func(i, j int) bool {
+		return declared[i].Port < declared[j].Port
+	})
+	return declared
+}
+
+func isComposeFile(name string) bool {
+	for _, prefix := range []string{"docker-compose", "compose"} {
+		if strings.HasPrefix(name, prefix) && (strings.HasSuffix(name, ".yml") || strings.HasSuffix(name, ".yaml")) {
+			return true
+		}
+	}
+	return false
+}
+
+// isEnvFile .env, .env.local 등. .env.example 같은 템플릿은 실제 설정이 아니므로 제외합니다.
+func isEnvFile(name string) bool {
+	if name == ".env" {
+		return true
+	}
+	suffix, ok := strings.CutPrefix(name, ".env.")
+	if !ok {
+		return false
+	}
+	switch suffix {
+	case "example", "sample", "template", "dist":
+		return false
+	}
+	return true
+}
+
+func isSpringConfig(name string) bool {
+	if !strings.HasPrefix(name, "application") {
+		return false
+	}
+	ext := filepath.Ext(name)
+	return ext == ".yml" || ext == ".yaml" || ext == ".properties"
+}
+
+// scanEnvFile PORT, DB_PORT 등 *PORT 변수를 찾습니다.
+func scanEnvFile(path string) []DeclaredPort {
+	var result []DeclaredPort
+	forEachLine(path, func(n int, line string) {
+		m := envPortLine.FindStringSubmatch(line)
+		if m == nil {
+			return
+		}
+		if port, err := strconv.Atoi(m[2]); err == nil && validPort(port) {
+			result = append(result, DeclaredPort{Port: port, Protocol: "tcp", Source: source(path, n), Name: m[1]})
+		}
+	})
+	return result
+}
+
+// readEnvFile compose 변수 치환에 쓸 .env 값을 읽습니다. 파일이 없으면 빈 맵입니다.
+func readEnvFile(path string) map[string]string {
+	env := make(map[string]string)
+	forEachLine(path, func(n int, line string) {
+		m := envLine.FindStringSubmatch(line)
+		if m == nil {
+			return
+		}
+		value := strings.TrimSpace(m[2])
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		} else if i := strings.Index(value, " #"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+		env[m[1]] = value
+	})
+	return env
+}
+
+// scanPackageJSON npm 스크립트의 --port N, -p N, PORT=N
+func scanPackageJSON(path string) []DeclaredPort {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if json.Unmarshal(data, &pkg) != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(pkg.Scripts))
+	for name := range pkg.Scripts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []DeclaredPort
+	for _, name := range names {
+		for _, m := range scriptPort.FindAllStringSubmatch(pkg.Scripts[name], -1) {
+			if port, err := strconv.Atoi(m[1]); err == nil && validPort(port) {
+				result = append(result, DeclaredPort{Port: port, Protocol: "tcp", Source: path, Name: "npm run " + name})
+			}
+		}
+	}
+	return result
+}
+
+// scanSpringConfig application.yml의 server: port:, server.port, application.properties의 server.port
+func scanSpringConfig(path string) []DeclaredPort {
+	var result []DeclaredPort
+	add := func(n int, value string) {
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		if m := springPlaced.FindStringSubmatch(value); m != nil {
+			value = m[1] // ${PORT:8080} → 기본값
+		}
+		if port, err := strconv.Atoi(value); err == nil && validPort(port) {
+			result = append(result, DeclaredPort{Port: port, Protocol: "tcp", Source: source(path, n), Name: "server.port"})
+		}
+	}
+
+	serverIndent := -1
+	forEachLine(path, func(n int, line string) {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			return
+		}
+		if m := springPort.FindStringSubmatch(line); m != nil {
+			add(n, m[1])
+			return
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if serverIndent >= 0 && indent <= serverIndent {
+			serverIndent = -1
+		}
+		if trimmed == "server:" {
+			serverIndent = indent
+			return
+		}
+		if serverIndent >= 0 && strings.HasPrefix(trimmed, "port:") {
+			add(n, strings.TrimPrefix(trimmed, "port:"))
+		}
+	})
+	return result
+}
+
+// scanComposeFile services.<name>.ports의 호스트 포트 (짧은 문법과 published: 긴 문법)
+func scanComposeFile(path string, env map[string]string) []DeclaredPort {
+	var result []DeclaredPort
+	service := ""
+	servicesIndent, serviceIndent, portsIndent := -1, -1, -1
+	var long *DeclaredPort // 긴 문법 항목 (published/protocol)
+
+	flush := func() {
+		if long != nil && long.Port > 0 {
+			result = append(result, *long)
+		}
+		long = nil
+	}
+
+	forEachLine(path, func(n int, line string) {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			return
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		if portsIndent >= 0 && indent <= portsIndent && !(indent == portsIndent && strings.HasPrefix(trimmed, "- ")) {
+			flush()
+			portsIndent = -1
+		}
+
+		if servicesIndent >= 0 && indent <= servicesIndent {
+			servicesIndent, serviceIndent, service = -1, -1, ""
+		}
+
+		switch {
+		case trimmed == "services:":
+			servicesIndent = indent
+		case servicesIndent >= 0 && indent > servicesIndent && (serviceIndent < 0 || indent == serviceIndent) && strings.HasSuffix(trimmed, ":"):
+			serviceIndent = indent
+			service = strings.TrimSuffix(trimmed, ":")
+		case trimmed == "ports:":
+			portsIndent = indent
+		case portsIndent >= 0 && strings.HasPrefix(trimmed, "- "):
+			flush()
+			entry := strings.TrimSpace(strings.TrimPrefix(trimmed, "- "))
+			if key, value, ok := strings.Cut(entry, ":"); ok && isComposeLongKey(key) {
+				long = &DeclaredPort{Protocol: "tcp", Source: source(path, n), Name: service}
+				applyLongSyntax(long, key, value, env)
+				return
+			}
+			for _, d := range parseComposePort(expandEnv(strings.Trim(entry, `"'`), env)) {
+				d.Source, d.Name = source(path, n), service
+				result = append(result, d)
+			}
+		case portsIndent >= 0 && long != nil:
+			if key, value, ok := strings.Cut(trimmed, ":"); ok {
+				applyLongSyntax(long, key, value, env)
+			}
+		}
+	})
+	flush()
+	return result
+}
+
+func isComposeLongKey(key string) bool {
+	switch key {
+	case "target", "published", "protocol", "host_ip", "mode", "name", "app_protocol":
+		return true
+	}
+	return false
+}
+
+func applyLongSyntax(d *DeclaredPort, key, value string, env map[string]string) {
+	value = strings.Trim(strings.TrimSpace(expandEnv(value, env)), `"'`)
+	switch key {
+	case "published":
+		// 범위("8000-8001")면 첫 포트
+		first, _, _ := strings.Cut(value, "-")
+		if port, err := strconv.Atoi(first); err == nil && validPort(port) {
+			d.Port = port
+		}
+	case "protocol":
+		d.Protocol = value
+	}
+}
+
+// parseComposePort "[host_ip:]host:container[/proto]" 짧은 문법에서 호스트 포트를 꺼냅니다.
+// 컨테이너 포트만 있으면("6379") 호스트 포트가 임의로 정해지므로 건너뜁니다.
+func parseComposePort(spec string) []DeclaredPort {
+	proto := "tcp"
+	if i := strings.LastIndex(spec, "/"); i >= 0 {
+		proto = spec[i+1:]
+		spec = spec[:i]
+	}
+
+	// IPv6 host_ip ("[::1]:5432:5432")
+	if strings.HasPrefix(spec, "[") {
+		if i := strings.Index(spec, "]:"); i >= 0 {
+			spec = spec[i+2:]
+		}
+	}
+	parts := strings.Split(spec, ":")
+	if len(parts) < 2 {
+		return nil
+	}
+	host := parts[len(parts)-2]
+
+	from, to, err := parsePortRange(host)
+	if err != nil {
+		return nil
+	}
+	var result []DeclaredPort
+	for port := from; port <= to; port++ {
+		result = append(result, DeclaredPort{Port: port, Protocol: proto})
+	}
+	return result
+}
+
+// expandEnv ${VAR}, ${VAR:-default}를 docker compose와 같은 우선순위(셸 환경 변수, .env, 기본값)로 치환합니다.
+func expandEnv(s string, env map[string]string) string {
+	return composeVar.ReplaceAllStringFunc(s, func(m string) string {
+		sub := composeVar.FindStringSubmatch(m)
+		if v, ok := os.LookupEnv(sub[1]); ok {
+			return v
+		}
+		if v, ok := env[sub[1]]; ok {
+			return v
+		}
+		return sub[2]
+	})
+}
+
+// markOccupied 선언된 포트를 사용 중인 리스너를 찾아 표시하고 충돌 수를 반환합니다.
+func markOccupied(declared []DeclaredPort, ports []PortInfo) int {
+	conflicts := 0
+	for i := range declared {
+		d := &declared[i]
+		for _, p := range ports {
+			if !isListener(p) || p.Port != d.Port || !strings.EqualFold(strings.TrimSuffix(p.Protocol, "6"), d.Protocol) {
+				continue
+			}
+			d.Occupied, d.PID, d.Command, d.Owner = true, p.PID, p.Command, p.Container
+			conflicts++
+			break
+		}
+	}
+	return conflicts
+}
+
+func printCheckTable(declared []DeclaredPort, conflicts int) {
+	common.Header("프로젝트 포트 충돌 검사")
+	fmt.Println()
+
+	fmt.Printf("%s%-7s %-6s %-24s %-40s %s%s\n", common.Bold, "PORT", "PROTO", "NAME", "SOURCE", "STATUS", common.Reset)
+	fmt.Println(strings.Repeat("─", 110))
+
+	for _, d := range declared {
+		status := common.Green + "사용 가능" + common.Reset
+		if d.Occupied {
+			owner := fmt.Sprintf("%s (PID %s)", d.Command, d.PID)
+			if d.Owner != "" {
+				owner = fmt.Sprintf("🐳 %s", d.Owner)
+			}
+			status = fmt.Sprintf("%s사용 중: %s%s", common.Red, owner, common.Reset)
+		}
+		fmt.Printf("%-7d %-6s %-24s %-40s %s\n", d.Port, d.Protocol, truncate(d.Name, 24), truncate(d.Source, 40), status)
+	}
+
+	fmt.Println()
+	if conflicts > 0 {
+		common.Error("%d개 포트가 이미 사용 중입니다", conflicts)
+	} else {
+		common.Success("선언된 %d개 포트가 모두 사용 가능합니다", len(declared))
+	}
+}
+
+func forEachLine(path string, fn func(n int, line string)) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		fn(n, strings.TrimRight(scanner.Text(), "\r"))
+	}
+}
+
+func source(path string, line int) string {
+	return fmt.Sprintf("%s:%d", path, line)
+}
+
+func validPort(port int) bool {
+	return port >= 1 && port <= 65535
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/useful-go/pkg/proc"
+)
+
+// PortFilter getPortList 필터 조건
+type PortFilter struct {
+	TCPOnly  bool
+	UDPOnly  bool
+	State    string         // LISTEN, ESTABLISHED 등 (대문자)
+	User     string         // 프로세스 소유자
+	Command  *regexp.Regexp // 명령어 이름 정규식
+	PortFrom int            // 포트 범위 (0이면 제한 없음)
+	PortTo   int
+}
+
+// MatchSocket 소켓이 필터 조건을 모두 만족하는지 확인합니다.
+func (f PortFilter) MatchSocket(s proc.Socket) bool {
+	// 프로토콜 필터
+	if f.TCPOnly && !strings.HasPrefix(s.Protocol, "TCP") {
+		return false
+	}
+	if f.UDPOnly && !strings.HasPrefix(s.Protocol, "UDP") {
+		return false
+	}
+
+	if f.State != "" && s.State != f.State {
+		return false
+	}
+	if f.User != "" && s.User != f.User {
+		return false
+	}
+	if f.Command != nil && !f.Command.MatchString(s.Command) {
+		return false
+	}
+
+	// 포트 범위 필터
+	if f.PortFrom > 0 && (s.Port < f.PortFrom || s.Port > f.PortTo) {
+		return false
+	}
+	return true
+}
+
+// parsePortRange "3000" 또는 "3000-3999"를 범위로 변환합니다.
+func parsePortRange(s string) (int, int, error) {
+	lo, hi, isRange := strings.Cut(s, "-")
+	from, err1 := strconv.Atoi(strings.TrimSpace(lo))
+	to := from
+	var err2 error
+	if isRange {
+		to, err2 = strconv.Atoi(strings.TrimSpace(hi))
+	}
+	if err1 != nil || err2 != nil || from < 1 || to > 65535 || from > to {
+		return 0, 0, fmt.Errorf("유효하지 않은 포트 범위: %s", s)
+	}
+	return from, to, nil
+}
+
+// 정렬 기준
+var sortKeys = []string{"port", "pid", "cpu", "mem", "command", "user"}
+
+func validSortKey(key string) bool {
+	for _, k := range sortKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// sortPorts 정렬 기준으로 정렬합니다. cpu/mem은 높은 순이며, reverse면 순서를 뒤집습니다.
+func sortPorts(ports []PortInfo, key string, reverse bool) {
+	sort.SliceStable(ports, func(i, j int) bool {
+		if reverse {
+			i, j = j, i
+		}
+		return portLess(ports[i], ports[j], key)
+	})
+}
+
+func portLess(a, b PortInfo, key string) bool {
+	switch key {
+	case "pid":
+		pa, _ := strconv.Atoi(a.PID)
+		pb, _ := strconv.Atoi(b.PID)
+		if pa != pb {
+			return pa < pb
+		}
+	case "cpu":
+		if ca, cb := parseFloat(a.CPU), parseFloat(b.CPU); ca != cb {
+			return ca > cb
+		}
+	case "mem":
+		if ma, mb := parseFloat(a.Mem), parseFloat(b.Mem); ma != mb {
+			return ma > mb
+		}
+	case "command":
+		if a.Command != b.Command {
+			return strings.ToLower(a.Command) < strings.ToLower(b.Command)
+		}
+	case "user":
+		if a.User != b.User {
+			return a.User < b.User
+		}
+	}
+	return a.Port < b.Port
+}
+
+func parseFloat(s string) float64 {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return -1
+	}
+	return v
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/useful-go/pkg/common"
+	"github.com/useful-go/pkg/proc"
+)
+
+// runFree 범위에서 사용 가능한 포트를 한 줄에 하나씩 출력합니다 (스크립트용).
+// 기본은 TCP이며, --udp면 UDP, --tcp --udp면 둘 다 비어 있는 포트를 찾습니다.
+func runFree(spec string, count int, host string, tcpOnly, udpOnly, jsonOut bool) {
+	from, to, err := parsePortRange(spec)
+	if err != nil {
+		common.Fatal("%v", err)
+	}
+	if count < 1 {
+		common.Fatal("--count는 1 이상이어야 합니다")
+	}
+
+	protos := []string{"tcp"}
+	switch {
+	case tcpOnly && udpOnly:
+		protos = []string{"tcp", "udp"}
+	case udpOnly:
+		protos = []string{"udp"}
+	}
+
+	ports, err := proc.FreePorts(host, from, to, count, protos)
+	if err != nil {
+		common.Error("%v", err)
+		os.Exit(1)
+	}
+
+	if jsonOut {
+		printJSON(ports)
+		return
+	}
+	for _, p := range ports {
+		fmt.Println(p)
+	}
+}
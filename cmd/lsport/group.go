@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/useful-go/pkg/common"
+)
+
+// ProcessGroup --group-by process 한 행 (프로세스와 그 포트들)
+type ProcessGroup struct {
+	PortInfo             // 대표 행 (가장 낮은 포트)
+	Ports       []int    `json:"ports"` // 로컬 포트 (중복 제거, 오름차순)
+	Protocols   []string `json:"protocols"`
+	States      []string `json:"states"`
+	Listeners   int      `json:"listeners"`
+	Connections int      `json:"connections"` // 리스너들의 ESTABLISHED 합계
+}
+
+// groupByProcess 포트 목록을 PID별로 묶습니다. 정렬 기준은 대표 행에 적용됩니다.
+func groupByProcess(ports []PortInfo, sortKey string, reverse bool) []ProcessGroup {
+	byPID := make(map[string]*ProcessGroup)
+	var order []string
+
+	for _, p := range ports {
+		g, ok := byPID[p.PID]
+		if !ok {
+			g = &ProcessGroup{PortInfo: p}
+			byPID[p.PID] = g
+			order = append(order, p.PID)
+		}
+		if p.Port < g.Port {
+			g.Port = p.Port
+		}
+		if !containsInt(g.Ports, p.Port) {
+			g.Ports = append(g.Ports, p.Port)
+		}
+		if !containsString(g.Protocols, protoLabel(p)) {
+			g.Protocols = append(g.Protocols, protoLabel(p))
+		}
+		if p.State != "" && !containsString(g.States, p.State) {
+			g.States = append(g.States, p.State)
+		}
+		if isListener(p) {
+			g.Listeners++
+		}
+		g.Connections += p.Established
+	}
+
+	groups := make([]ProcessGroup, 0, len(order))
+	for _, pid := range order {
+		g := byPID[pid]
+		sort.Ints(g.Ports)
+		groups = append(groups, *g)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if reverse {
+			i, j = j, i
+		}
+		return portLess(groups[i].PortInfo, groups[j].PortInfo, sortKey)
+	})
+	return groups
+}
+
+func printProcessTable(groups []ProcessGroup) {
+	common.Header("프로세스별 포트 목록")
+	fmt.Println()
+
+	fmt.Printf("%s%-8s %-18s %-12s %-7s %-7s %-10s %-9s %-6s %-20s %s%s\n",
+		common.Bold, "PID", "COMMAND", "USER", "CPU%", "MEM%", "PROTO", "LISTENERS", "CONN", "STATES", "PORTS", common.Reset)
+	fmt.Println(strings.Repeat("─", 120))
+
+	var total int
+	for _, g := range groups {
+		cpuColor := getCPUColor(g.CPU)
+		memColor := getMemColor(g.Mem)
+		fmt.Printf("%-8s %-18s %-12s %s%-7s%s %s%-7s%s %-10s %-9d %-6d %-20s %s\n",
+			g.PID, truncate(g.Command, 18), truncate(g.User, 12),
+			cpuColor, g.CPU, common.Reset,
+			memColor, g.Mem, common.Reset,
+			strings.Join(g.Protocols, ","), g.Listeners, g.Connections,
+			truncate(strings.Join(g.States, ","), 20), joinPorts(g.Ports))
+		total += len(g.Ports)
+	}
+
+	fmt.Println()
+	common.Info("총 %d개 프로세스, %d개 포트", len(groups), total)
+}
+
+func joinPorts(ports []int) string {
+	parts := make([]string, len(ports))
+	for i, p := range ports {
+		parts[i] = strconv.Itoa(p)
+	}
+	return strings.Join(parts, ",")
+}
+
+func containsInt(list []int, v int) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, v string) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/useful-go/pkg/common"
+)
+
+// -i 모드에서 목록 아래에 표시하는 상세 정보 줄 수 (구분선 포함)
+const detailHeight = 7
+
+// renderDetail 선택한 포트의 전체 명령줄, 작업 디렉토리, 연결 현황을 그립니다.
+func renderDetail(b *strings.Builder, p PortInfo, closed bool, cols int) {
+	lines := make([]string, 0, detailHeight)
+	lines = append(lines, strings.Repeat("─", cols))
+
+	owner := fmt.Sprintf("%sPID %s%s %s  사용자 %s  가동 %s", common.Bold, p.PID, common.Reset, p.Command, p.User, formatUptime(p))
+	if p.Service != "" {
+		owner += "  " + common.Cyan + p.Service + common.Reset
+	}
+	if closed {
+		owner += "  " + common.Red + "(닫힘)" + common.Reset
+	}
+	lines = append(lines, owner)
+	lines = append(lines, clip("CMD  "+valueOr(p.Args, "-"), cols))
+	lines = append(lines, clip("CWD  "+valueOr(p.Cwd, "-"), cols))
+
+	switch {
+	case p.Container != "":
+		lines = append(lines, fmt.Sprintf("%s🐳 %s (%s)%s  k는 컨테이너를 중지합니다", common.Blue, p.Container, p.Image, common.Reset))
+	case p.RemotePort > 0:
+		lines = append(lines, "원격 "+formatAddr(p.RemoteAddr, p.RemotePort))
+	default:
+		lines = append(lines, "")
+	}
+
+	if p.Protocol == "TCP" && p.State == "LISTEN" {
+		conn := "연결 " + formatConnections(p)
+		var peers []string
+		for _, addr := range sortedPeers(p) {
+			peers = append(peers, fmt.Sprintf("%s×%d", addr, p.Peers[addr]))
+		}
+		if len(peers) > 0 {
+			conn += "  peer: " + strings.Join(peers, ", ")
+		}
+		lines = append(lines, clip(conn, cols))
+	}
+
+	for len(lines) < detailHeight {
+		lines = append(lines, "")
+	}
+	for _, l := range lines[:detailHeight] {
+		b.WriteString(l + "\n")
+	}
+}
+
+// sortedPeers ESTABLISHED 연결이 많은 원격 주소 순서
+func sortedPeers(p PortInfo) []string {
+	peers := make([]string, 0, len(p.Peers))
+	for addr := range p.Peers {
+		peers = append(peers, addr)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		if p.Peers[peers[i]] != p.Peers[peers[j]] {
+			return p.Peers[peers[i]] > p.Peers[peers[j]]
+		}
+		return peers[i] < peers[j]
+	})
+	return peers
+}
+
+// fuzzyMatch pattern의 글자가 s에 순서대로 모두 나타나는지 확인합니다 (대소문자 무시).
+// 예: "nxd"는 "next-dev-server"와 일치합니다.
+func fuzzyMatch(pattern, s string) bool {
+	s = strings.ToLower(s)
+	for _, r := range strings.ToLower(pattern) {
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
+		}
+		s = s[i+utf8.RuneLen(r):]
+	}
+	return true
+}
@@ -1,62 +1,260 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
-	"os/exec"
+	"os"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/useful-go/pkg/common"
+	"github.com/useful-go/pkg/docker"
+	"github.com/useful-go/pkg/proc"
 )
 
+// PortInfo 포트 한 행. --json 출력과 스냅샷 파일에 그대로 사용됩니다.
 type PortInfo struct {
-	Port     int
-	Protocol string
-	PID      string
-	Command  string
-	User     string
-	State    string
-	CPU      string
-	Mem      string
+	Port       int    `json:"port"`
+	Protocol   string `json:"protocol"`
+	Family     string `json:"family"`                // IPv4, IPv6
+	LocalAddr  string `json:"local_addr"`            // bind 주소 ("*"는 모든 인터페이스)
+	RemoteAddr string `json:"remote_addr,omitempty"` // 연결된 소켓의 원격 주소
+	RemotePort int    `json:"remote_port,omitempty"`
+	PID        string `json:"pid"`
+	Command    string `json:"command"`
+	User       string `json:"user"`
+	State      string `json:"state,omitempty"`
+	CPU        string `json:"cpu"`
+	Mem        string `json:"mem"`
+	Container  string `json:"container,omitempty"` // Docker 게시 포트인 경우 컨테이너 이름
+	Image      string `json:"image,omitempty"`
+	Service    string `json:"service,omitempty"` // 잘 알려진 서비스 이름 또는 감지한 개발 서버
+
+	Probe *ProbeResult `json:"probe,omitempty"` // --probe 결과 (루프백 TCP 리스너)
+
+	// 점유 프로세스 정보
+	Args      string     `json:"args,omitempty"`    // 전체 명령줄
+	Cwd       string     `json:"cwd,omitempty"`     // 작업 디렉토리 (프로젝트 구분용)
+	Started   *time.Time `json:"started,omitempty"` // 조회하지 못하면 nil
+	UptimeSec int64      `json:"uptime_sec,omitempty"`
+
+	// TCP 리스너의 연결 현황
+	Established int            `json:"established,omitempty"`
+	CloseWait   int            `json:"close_wait,omitempty"`
+	TimeWait    int            `json:"time_wait,omitempty"`
+	Peers       map[string]int `json:"peers,omitempty"` // 원격 주소 → ESTABLISHED 연결 수
 }
 
 func main() {
 	tcpOnly := flag.Bool("tcp", false, "TCP 포트만 표시")
 	udpOnly := flag.Bool("udp", false, "UDP 포트만 표시")
 	listen := flag.Bool("listen", false, "LISTEN 상태만 표시")
-	portFilter := flag.Int("port", 0, "특정 포트만 표시")
+	portFlag := flag.String("port", "", "특정 포트 또는 범위만 표시 (예: 3000, 3000-3999)")
+	stateFlag := flag.String("state", "", "특정 상태만 표시 (예: ESTABLISHED)")
+	userFlag := flag.String("user", "", "특정 사용자의 프로세스만 표시")
+	commandFlag := flag.String("command", "", "명령어 이름 정규식 필터")
+	sortKey := flag.String("sort", "port", "정렬 기준 (port|pid|cpu|mem|command|user)")
+	reverse := flag.Bool("reverse", false, "정렬 순서 반대로")
+	groupBy := flag.String("group-by", "", "그룹화 기준 (process: 프로세스당 한 행)")
+	watch := flag.Bool("watch", false, "top처럼 주기적으로 갱신 (키보드로 정렬/필터/종료)")
+	interactive := flag.Bool("interactive", false, "상세 정보 창이 있는 대화형 목록 (선택, 퍼지 필터, 종료)")
+	flag.BoolVar(interactive, "i", false, "--interactive")
+	interval := flag.Duration("interval", 2*time.Second, "--watch 갱신 주기")
+	peers := flag.Bool("peers", false, "리스너별 연결 상태와 원격 peer 요약 표시")
+	exposed := flag.Bool("exposed", false, "모든 인터페이스/외부 주소에 bind된 리스너만 보안 경고와 함께 표시")
+	freeRange := flag.String("free", "", "범위에서 사용 가능한 포트 찾기 (예: 3000-3999)")
+	count := flag.Int("count", 1, "--free로 찾을 포트 수")
+	bindHost := flag.String("bind", "", "--free에서 bind를 시도할 주소 (기본: 모든 인터페이스)")
+	unixFlag := flag.Bool("unix", false, "Unix 도메인 소켓 표시 (경로, 소유 프로세스, stream/dgram)")
+	serveAddr := flag.String("addr", "127.0.0.1:9740", "lsport serve 주소")
+	probeFlag := flag.Bool("probe", false, "루프백 리스너에 TCP 연결과 HTTP GET /을 시도해 상태 코드, Server, <title> 표시")
+	wide := flag.Bool("wide", false, "작업 디렉토리, 가동 시간, 전체 명령줄 표시")
+	jsonOut := flag.Bool("json", false, "JSON으로 출력")
 	help := flag.Bool("help", false, "도움말")
 	flag.BoolVar(help, "h", false, "도움말")
 
+	// 하위 명령 뒤에 오는 옵션도 허용 (lsport snapshot save before --listen)
 	flag.Parse()
+	var subcommand string
+	var subArgs []string
+	if flag.NArg() > 0 {
+		subcommand = flag.Arg(0)
+		n := map[string]int{"snapshot": 2, "diff": 1, "check": 1}[subcommand]
+		rest := flag.Args()[1:]
+		for len(subArgs) < n && len(rest) > 0 && !strings.HasPrefix(rest[0], "-") {
+			subArgs = append(subArgs, rest[0])
+			rest = rest[1:]
+		}
+		flag.CommandLine.Parse(rest)
+	}
 
 	if *help {
 		printUsage()
 		return
 	}
 
-	ports := getPortList(*tcpOnly, *udpOnly, *listen, *portFilter)
+	filter := PortFilter{
+		TCPOnly: *tcpOnly,
+		UDPOnly: *udpOnly,
+		State:   strings.ToUpper(*stateFlag),
+		User:    *userFlag,
+	}
+	if *listen {
+		filter.State = "LISTEN"
+	}
+	if *portFlag != "" {
+		from, to, err := parsePortRange(*portFlag)
+		if err != nil {
+			common.Fatal("%v", err)
+		}
+		filter.PortFrom, filter.PortTo = from, to
+	}
+	if *commandFlag != "" {
+		re, err := regexp.Compile(*commandFlag)
+		if err != nil {
+			common.Fatal("유효하지 않은 --command 정규식: %v", err)
+		}
+		filter.Command = re
+	}
+	if !validSortKey(*sortKey) {
+		common.Fatal("유효하지 않은 정렬 기준: %s (%s)", *sortKey, strings.Join(sortKeys, "|"))
+	}
+	if *groupBy != "" && *groupBy != "process" {
+		common.Fatal("유효하지 않은 그룹화 기준: %s (process)", *groupBy)
+	}
+
+	services := loadServices()
+
+	switch subcommand {
+	case "":
+	case "snapshot":
+		runSnapshot(subArgs, getPortList(filter, services))
+		return
+	case "diff":
+		runDiff(subArgs, getPortList(filter, services), *jsonOut)
+		return
+	case "serve":
+		runServe(*serveAddr, filter, services)
+		return
+	case "check":
+		runCheck(subArgs, services, *jsonOut)
+		return
+	default:
+		common.Fatal("알 수 없는 명령: %s (snapshot, diff, serve, check)", subcommand)
+	}
+	if flag.NArg() > 0 {
+		common.Fatal("알 수 없는 인자: %s", strings.Join(flag.Args(), " "))
+	}
+
+	if *freeRange != "" {
+		runFree(*freeRange, *count, *bindHost, *tcpOnly, *udpOnly, *jsonOut)
+		return
+	}
+
+	if *unixFlag {
+		list, err := getUnixList(filter)
+		if err != nil {
+			common.Fatal("Unix 소켓 조회 실패: %v", err)
+		}
+		if *jsonOut {
+			printJSON(list)
+		} else if len(list) == 0 {
+			common.Warning("Unix 도메인 소켓이 없습니다")
+		} else {
+			printUnixTable(list)
+		}
+		return
+	}
+
+	if *watch || *interactive {
+		if *interval <= 0 {
+			common.Fatal("유효하지 않은 갱신 주기: %s (0보다 커야 합니다)", *interval)
+		}
+		runWatch(*interval, *sortKey, *interactive, func() []PortInfo {
+			return getPortList(filter, services)
+		})
+		return
+	}
+
+	ports := getPortList(filter, services)
+	sortPorts(ports, *sortKey, *reverse)
+
+	if *exposed {
+		ports = filterExposed(ports)
+	}
+	if *probeFlag {
+		probePorts(ports)
+	}
+
+	if *jsonOut {
+		if *groupBy == "process" {
+			printJSON(groupByProcess(ports, *sortKey, *reverse))
+		} else {
+			printJSON(ports)
+		}
+		return
+	}
+
+	if *exposed {
+		if len(ports) == 0 {
+			common.Success("외부에 노출된 리스너가 없습니다 (모두 루프백에 bind됨)")
+			return
+		}
+		printPortTable(ports, *wide)
+		printExposureWarnings(ports)
+		return
+	}
+
 	if len(ports) == 0 {
 		common.Warning("사용 중인 포트가 없습니다")
 		return
 	}
 
-	printPortTable(ports)
+	if *groupBy == "process" {
+		printProcessTable(groupByProcess(ports, *sortKey, *reverse))
+	} else {
+		printPortTable(ports, *wide)
+	}
+	if *peers {
+		printPeerSummary(ports)
+	}
 }
 
 func printUsage() {
 	common.Header("ls-port - 사용 중인 포트 목록 조회")
 	fmt.Println()
 	fmt.Println("사용법: lsport [options]")
+	fmt.Println("        lsport snapshot save <name> [options]")
+	fmt.Println("        lsport diff <name> [options]   # 변경이 있으면 종료 코드 1")
+	fmt.Println("        lsport check [dir]             # 프로젝트에 선언된 포트 충돌 검사")
+	fmt.Println("        lsport serve [--addr 127.0.0.1:9740] [options]   # /ports JSON, /metrics Prometheus")
 	fmt.Println()
 	fmt.Println("옵션:")
 	fmt.Println("  --tcp          TCP 포트만 표시")
 	fmt.Println("  --udp          UDP 포트만 표시")
 	fmt.Println("  --listen       LISTEN 상태만 표시")
-	fmt.Println("  --port N       특정 포트만 표시")
+	fmt.Println("  --port N[-M]   특정 포트 또는 범위만 표시")
+	fmt.Println("  --state S      특정 상태만 표시 (LISTEN, ESTABLISHED, CLOSE_WAIT 등)")
+	fmt.Println("  --user U       특정 사용자의 프로세스만 표시")
+	fmt.Println("  --command RE   명령어 이름 정규식 필터")
+	fmt.Println("  --sort KEY     정렬 기준: port|pid|cpu|mem|command|user (기본: port)")
+	fmt.Println("  --reverse      정렬 순서 반대로")
+	fmt.Println("  --group-by process  프로세스당 한 행으로 포트 묶어 표시")
+	fmt.Println("  --peers        리스너별 ESTABLISHED/CLOSE_WAIT/TIME_WAIT 수와 원격 peer 요약")
+	fmt.Println("  --exposed      0.0.0.0/[::] 또는 외부 주소에 bind된 리스너만 표시 (보안 경고)")
+	fmt.Println("  --free RANGE   범위에서 사용 가능한 포트 출력 (--count N, --bind ADDR, --udp)")
+	fmt.Println("  --unix         Unix 도메인 소켓 표시 (docker.sock, .s.PGSQL.5432 등)")
+	fmt.Println("  --probe        루프백 리스너에 HTTP GET /을 보내 상태 코드, Server, <title> 표시")
+	fmt.Println("  --wide         가동 시간, 작업 디렉토리(CWD), 전체 명령줄 표시")
+	fmt.Println("  --json         JSON으로 출력 (--group-by process와 함께 사용 가능)")
+	fmt.Println("  --watch        주기적으로 갱신 (새 포트 초록, 닫힌 포트 빨강)")
+	fmt.Println("  --interval D   --watch 갱신 주기 (기본: 2s)")
+	fmt.Println("  -i             대화형 목록: 상세 정보(전체 명령줄, CWD, 연결), 퍼지 필터, k로 종료")
 	fmt.Println("  -h, --help     도움말")
 	fmt.Println()
 	fmt.Println("예시:")
@@ -64,129 +262,380 @@ func printUsage() {
 	fmt.Println("  lsport --tcp        # TCP만 표시")
 	fmt.Println("  lsport --listen     # 리스닝 포트만 표시")
 	fmt.Println("  lsport --port 3000  # 3000번 포트만 표시")
+	fmt.Println("  lsport --port 3000-3999 --command node --sort mem")
+	fmt.Println("  lsport --listen --group-by process")
+	fmt.Println("  lsport --exposed    # 외부에 노출된 리스너 점검")
+	fmt.Println("  lsport --listen --peers  # 커넥션 풀 누수 점검")
+	fmt.Println("  lsport --watch --listen --interval 1s")
+	fmt.Println("  PORT=$(lsport --free 3000-3999)")
+	fmt.Println("  lsport --free 8000-8999 --count 3 --bind 127.0.0.1")
+	fmt.Println("  lsport snapshot save before && npm test && lsport diff before")
+	fmt.Println()
+	fmt.Println("--watch, -i 단축키: ↑↓ 선택, s 정렬 변경, / 필터, k 선택 프로세스 종료, q 종료")
 }
 
-func getPortList(tcpOnly, udpOnly, listenOnly bool, portFilter int) []PortInfo {
-	// lsof -i -P -n: 네트워크 연결 정보, 포트 숫자로 표시, DNS 해석 안함
-	cmd := exec.Command("lsof", "-i", "-P", "-n")
-	output, err := cmd.Output()
+func getPortList(filter PortFilter, services map[string]string) []PortInfo {
+	sockets, err := proc.ListSockets()
 	if err != nil {
 		return nil
 	}
 
-	var ports []PortInfo
+	// 표시할 리스너의 TCP 포트 (수락된 연결은 리스너 행의 연결 수로 합침)
+	// 필터로 리스너 행이 빠지면(--state, --user, --command 등) 수락된 연결을 그대로 표시
+	listening := make(map[int]bool)
+	for _, s := range sockets {
+		if s.Protocol == "TCP" && s.State == "LISTEN" && filter.MatchSocket(s) {
+			listening[s.Port] = true
+		}
+	}
+
+	ports := []PortInfo{}
 	seen := make(map[string]bool)
-	lines := strings.Split(string(output), "\n")
 
-	// 포트 추출 정규식
-	portRegex := regexp.MustCompile(`:(\d+)(?:\s|$|->)`)
+	for _, s := range sockets {
+		if s.Protocol == "TCP" && s.State != "LISTEN" && listening[s.Port] {
+			continue
+		}
 
-	for i, line := range lines {
-		if i == 0 || strings.TrimSpace(line) == "" {
-			continue // 헤더 스킵
+		if !filter.MatchSocket(s) {
+			continue
 		}
 
-		fields := strings.Fields(line)
-		if len(fields) < 9 {
+		// 중복 제거 (같은 포트/프로토콜/bind 주소/프로세스/원격 주소)
+		key := fmt.Sprintf("%d-%s-%s-%s-%s:%d", s.Port, s.Protocol, s.LocalAddr, s.PID, s.RemoteAddr, s.RemotePort)
+		if seen[key] {
 			continue
 		}
+		seen[key] = true
+
+		ports = append(ports, PortInfo{
+			Port:       s.Port,
+			Protocol:   s.Protocol,
+			Family:     s.Family,
+			LocalAddr:  s.LocalAddr,
+			RemoteAddr: s.RemoteAddr,
+			RemotePort: s.RemotePort,
+			PID:        s.PID,
+			Command:    truncate(s.Command, 20),
+			User:       s.User,
+			State:      s.State,
+		})
+	}
 
-		command := fields[0]
-		pid := fields[1]
-		user := fields[2]
-		protocol := strings.ToUpper(fields[7]) // TCP, UDP
-		name := fields[8]                       // 연결 정보
+	fillStats(ports)
+	fillProcessInfo(ports)
+	fillConnections(ports)
+	annotateContainers(ports)
+	annotateServices(ports, services)
 
-		// 프로토콜 필터
-		if tcpOnly && !strings.HasPrefix(protocol, "TCP") {
-			continue
+	// 포트 번호로 정렬
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i].Port < ports[j].Port
+	})
+
+	return ports
+}
+
+// fillStats 모든 PID의 CPU, 메모리 사용량을 한 번의 ps 호출로 채웁니다.
+func fillStats(ports []PortInfo) {
+	pids := make([]string, len(ports))
+	for i, p := range ports {
+		pids[i] = p.PID
+	}
+
+	stats := proc.BatchStats(pids)
+	for i := range ports {
+		ports[i].CPU, ports[i].Mem = "-", "-"
+		if u, ok := stats[ports[i].PID]; ok {
+			ports[i].CPU, ports[i].Mem = u.CPU, u.Mem
 		}
-		if udpOnly && !strings.HasPrefix(protocol, "UDP") {
-			continue
+	}
+}
+
+// fillProcessInfo 점유 프로세스의 전체 명령줄, 작업 디렉토리, 시작 시각을 채웁니다.
+func fillProcessInfo(ports []PortInfo) {
+	pids := make([]string, len(ports))
+	for i, p := range ports {
+		pids[i] = p.PID
+	}
+
+	args := proc.CommandLines(pids)
+	dirs := proc.WorkingDirs(pids)
+	started := proc.StartTimes(pids)
+	now := time.Now()
+
+	for i := range ports {
+		p := &ports[i]
+		p.Args = args[p.PID]
+		p.Cwd = dirs[p.PID]
+		if t, ok := started[p.PID]; ok {
+			p.Started = &t
+			p.UptimeSec = int64(now.Sub(t).Seconds())
 		}
+	}
+}
 
-		// 상태 확인
-		state := ""
-		if len(fields) >= 10 {
-			state = fields[9]
-			// 괄호 제거: (LISTEN) -> LISTEN
-			state = strings.Trim(state, "()")
+// fillConnections TCP 리스너별 ESTABLISHED/CLOSE_WAIT/TIME_WAIT 연결 수와 원격 peer를 채웁니다.
+// 같은 포트에 리스너가 여러 개면 주소가 정확히 일치하는 리스너, 없으면 와일드카드 리스너에 집계합니다.
+func fillConnections(ports []PortInfo) {
+	byPort := make(map[int][]int)
+	for i, p := range ports {
+		if p.Protocol == "TCP" && p.State == "LISTEN" {
+			byPort[p.Port] = append(byPort[p.Port], i)
 		}
+	}
+	if len(byPort) == 0 {
+		return
+	}
 
-		if listenOnly && state != "LISTEN" {
+	conns, err := proc.TCPTable()
+	if err != nil {
+		return
+	}
+
+	for _, c := range conns {
+		if c.State == "LISTEN" {
 			continue
 		}
-
-		// 로컬 포트 추출
-		matches := portRegex.FindStringSubmatch(name)
-		if len(matches) < 2 {
+		candidates := byPort[c.LocalPort]
+		if len(candidates) == 0 {
 			continue
 		}
 
-		port, err := strconv.Atoi(matches[1])
-		if err != nil {
+		idx := -1
+		for _, i := range candidates {
+			if ports[i].LocalAddr == c.LocalAddr {
+				idx = i
+				break
+			}
+			if idx < 0 && proc.IsWildcard(ports[i].LocalAddr) {
+				idx = i
+			}
+		}
+		if idx < 0 {
 			continue
 		}
 
-		// 포트 필터
-		if portFilter > 0 && port != portFilter {
-			continue
+		p := &ports[idx]
+		switch c.State {
+		case "ESTABLISHED":
+			p.Established++
+			if p.Peers == nil {
+				p.Peers = make(map[string]int)
+			}
+			p.Peers[c.RemoteAddr]++
+		case "CLOSE_WAIT":
+			p.CloseWait++
+		case "TIME_WAIT":
+			p.TimeWait++
 		}
+	}
+}
 
-		// 중복 제거 (같은 포트/프로토콜/프로세스)
-		key := fmt.Sprintf("%d-%s-%s", port, protocol, pid)
-		if seen[key] {
+// formatConnections 리스너의 연결 요약 ("12 (peer 3) CW 2 TW 5")
+func formatConnections(p PortInfo) string {
+	if p.Protocol != "TCP" || p.State != "LISTEN" {
+		return ""
+	}
+	s := fmt.Sprintf("%d (peer %d)", p.Established, len(p.Peers))
+	if p.CloseWait > 0 {
+		s += fmt.Sprintf(" %sCW %d%s", common.Yellow, p.CloseWait, common.Reset)
+	}
+	if p.TimeWait > 0 {
+		s += fmt.Sprintf(" TW %d", p.TimeWait)
+	}
+	return s
+}
+
+// printPeerSummary 리스너별 연결 상태와 원격 peer 목록을 출력합니다.
+func printPeerSummary(ports []PortInfo) {
+	fmt.Println()
+	common.Header("리스너별 연결 현황")
+	fmt.Println()
+
+	printed := 0
+	for _, p := range ports {
+		if p.Protocol != "TCP" || p.State != "LISTEN" {
 			continue
 		}
-		seen[key] = true
+		if p.Established == 0 && p.CloseWait == 0 && p.TimeWait == 0 {
+			continue
+		}
+		printed++
 
-		// CPU, 메모리 사용량 조회
-		cpu, mem := getProcessStats(pid)
+		fmt.Printf("%s%s%s %s (PID %s): ESTABLISHED %d, CLOSE_WAIT %d, TIME_WAIT %d\n",
+			common.Bold, formatAddr(p.LocalAddr, p.Port), common.Reset, p.Command, p.PID,
+			p.Established, p.CloseWait, p.TimeWait)
 
-		ports = append(ports, PortInfo{
-			Port:     port,
-			Protocol: protocol,
-			PID:      pid,
-			Command:  truncate(command, 20),
-			User:     user,
-			State:    state,
-			CPU:      cpu,
-			Mem:      mem,
-		})
+		for _, addr := range sortedPeers(p) {
+			fmt.Printf("    %-40s %d\n", addr, p.Peers[addr])
+		}
+		if p.CloseWait > 0 {
+			common.Warning("    CLOSE_WAIT %d개: 애플리케이션이 닫힌 연결을 정리하지 않고 있습니다 (커넥션 풀 누수 의심)", p.CloseWait)
+		}
 	}
 
-	// 포트 번호로 정렬
-	sort.Slice(ports, func(i, j int) bool {
-		return ports[i].Port < ports[j].Port
-	})
+	if printed == 0 {
+		fmt.Println("  연결된 클라이언트가 없습니다")
+	}
+}
 
-	return ports
+// annotateContainers Docker가 게시한 포트에 컨테이너 이름/이미지를 채웁니다.
+// 이 포트들은 lsof에서 docker-proxy, com.docker.backend 등 프록시 프로세스로 보입니다.
+func annotateContainers(ports []PortInfo) {
+	published, err := docker.PublishedPorts()
+	if err != nil || len(published) == 0 {
+		return
+	}
+
+	for i := range ports {
+		if !isListener(ports[i]) {
+			continue
+		}
+		if c, ok := published[docker.Key(ports[i].Port, ports[i].Protocol)]; ok {
+			ports[i].Container = c.Name
+			ports[i].Image = c.Image
+		}
+	}
 }
 
-func printPortTable(ports []PortInfo) {
+func printPortTable(ports []PortInfo, wide bool) {
 	common.Header("사용 중인 포트 목록")
 	fmt.Println()
 
+	hasContainer, hasProbe := false, false
+	for _, p := range ports {
+		if p.Container != "" {
+			hasContainer = true
+		}
+		if p.Probe != nil {
+			hasProbe = true
+		}
+	}
+
 	// 헤더
-	fmt.Printf("%s%-7s %-6s %-8s %-18s %-7s %-7s %-12s %-10s%s\n",
-		common.Bold, "PORT", "PROTO", "PID", "COMMAND", "CPU%", "MEM%", "USER", "STATE", common.Reset)
-	fmt.Println(strings.Repeat("─", 85))
+	header := fmt.Sprintf("%-7s %-6s %-22s %-8s %-18s %-18s %-7s %-7s %-12s %-11s %-22s %-18s",
+		"PORT", "PROTO", "ADDRESS", "PID", "COMMAND", "SERVICE", "CPU%", "MEM%", "USER", "STATE", "REMOTE", "CONNECTIONS")
+	if hasContainer {
+		header += " CONTAINER (IMAGE)"
+	}
+	if hasProbe {
+		header += fmt.Sprintf(" %-50s", "PROBE")
+	}
+	if wide {
+		header += fmt.Sprintf(" %-8s %-30s %s", "UPTIME", "CWD", "ARGS")
+	}
+	fmt.Println(common.Bold + header + common.Reset)
+	fmt.Println(strings.Repeat("─", 150))
 
 	for _, p := range ports {
 		stateColor := getStateColor(p.State)
 		cpuColor := getCPUColor(p.CPU)
 		memColor := getMemColor(p.Mem)
-		fmt.Printf("%-7d %-6s %-8s %-18s %s%-7s%s %s%-7s%s %-12s %s%-10s%s\n",
-			p.Port, p.Protocol, p.PID, truncate(p.Command, 18),
+		addrColor := ""
+		if isExposed(p) {
+			addrColor = common.Yellow
+		}
+		fmt.Printf("%-7d %-6s %s%-22s%s %-8s %-18s %s%-18s%s %s%-7s%s %s%-7s%s %-12s %s%-11s%s %-22s %s",
+			p.Port, protoLabel(p), addrColor, formatAddr(p.LocalAddr, p.Port), common.Reset,
+			p.PID, truncate(p.Command, 18),
+			common.Cyan, truncate(p.Service, 18), common.Reset,
 			cpuColor, p.CPU, common.Reset,
 			memColor, p.Mem, common.Reset,
-			p.User, stateColor, p.State, common.Reset)
+			p.User, stateColor, p.State, common.Reset,
+			formatAddr(p.RemoteAddr, p.RemotePort), padRight(formatConnections(p), 18))
+		if p.Container != "" {
+			fmt.Printf(" %s🐳 %s (%s)%s", common.Blue, p.Container, p.Image, common.Reset)
+		}
+		if hasProbe {
+			fmt.Printf(" %s", padRight(formatProbe(p.Probe), 50))
+		}
+		if wide {
+			fmt.Printf(" %-8s %-30s %s", formatUptime(p), p.Cwd, p.Args)
+		}
+		fmt.Println()
 	}
 
 	fmt.Println()
 	common.Info("총 %d개 포트 사용 중", len(ports))
 }
 
+// formatUptime 가동 시간 ("3d4h", "2h13m", "5m12s")
+func formatUptime(p PortInfo) string {
+	if p.Started == nil {
+		return "-"
+	}
+	d := time.Duration(p.UptimeSec) * time.Second
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd%dh", int(d.Hours())/24, int(d.Hours())%24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dm%ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+}
+
+func printJSON(v interface{}) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		common.Fatal("JSON 출력 실패: %v", err)
+	}
+}
+
+// isListener LISTEN 상태의 TCP 소켓 또는 연결되지 않은 UDP 소켓인지 확인합니다.
+func isListener(p PortInfo) bool {
+	return p.State == "LISTEN" || (p.Protocol == "UDP" && p.RemotePort == 0)
+}
+
+// isExposed 리스너가 루프백이 아닌 주소(모든 인터페이스 포함)에 bind되었는지 확인합니다.
+func isExposed(p PortInfo) bool {
+	return isListener(p) && !proc.IsLoopback(p.LocalAddr)
+}
+
+func filterExposed(ports []PortInfo) []PortInfo {
+	result := []PortInfo{}
+	for _, p := range ports {
+		if isExposed(p) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func printExposureWarnings(ports []PortInfo) {
+	fmt.Println()
+	for _, p := range ports {
+		where := fmt.Sprintf("외부 주소 %s", p.LocalAddr)
+		if proc.IsWildcard(p.LocalAddr) {
+			where = "모든 인터페이스"
+		}
+		common.Warning("%s/%d (%s, PID %s): %s에 노출됨", p.Protocol, p.Port, p.Command, p.PID, where)
+	}
+	fmt.Println()
+	common.Warning("총 %d개 리스너가 루프백 외 주소에 bind되어 있습니다. 로컬 전용 서비스는 127.0.0.1 또는 ::1에 bind하세요", len(ports))
+}
+
+// formatAddr 주소와 포트를 "addr:port" 형식으로 표시합니다 (IPv6는 [addr]:port).
+func formatAddr(addr string, port int) string {
+	if addr == "" && port == 0 {
+		return "-"
+	}
+	if strings.Contains(addr, ":") {
+		addr = "[" + addr + "]"
+	}
+	return fmt.Sprintf("%s:%d", addr, port)
+}
+
+// protoLabel IPv6 소켓은 TCP6/UDP6로 표시합니다.
+func protoLabel(p PortInfo) string {
+	if p.Family == "IPv6" {
+		return p.Protocol + "6"
+	}
+	return p.Protocol
+}
+
 func getCPUColor(cpu string) string {
 	val, err := strconv.ParseFloat(cpu, 64)
 	if err != nil {
@@ -226,29 +675,20 @@ func getStateColor(state string) string {
 	}
 }
 
-func truncate(s string, max int) string {
-	if len(s) <= max {
-		return s
+// padRight 색상 코드를 제외한 표시 길이 기준으로 공백을 채웁니다.
+func padRight(s string, width int) string {
+	visible := ansiRegex.ReplaceAllString(s, "")
+	if n := utf8.RuneCountInString(visible); n < width {
+		return s + strings.Repeat(" ", width-n)
 	}
-	return s[:max-1] + "…"
+	return s
 }
 
-// getProcessStats returns CPU%, MEM% for a given PID
-func getProcessStats(pid string) (cpu, mem string) {
-	cmd := exec.Command("ps", "-p", pid, "-o", "%cpu,%mem")
-	output, err := cmd.Output()
-	if err != nil {
-		return "-", "-"
-	}
+var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;]*m`)
 
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	if len(lines) < 2 {
-		return "-", "-"
-	}
-
-	fields := strings.Fields(lines[1])
-	if len(fields) >= 2 {
-		return fields[0], fields[1]
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
 	}
-	return "-", "-"
+	return s[:max-1] + "…"
 }
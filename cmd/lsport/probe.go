@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/useful-go/pkg/common"
+	"github.com/useful-go/pkg/proc"
+)
+
+// 프로브 한 건의 제한 시간 (TCP 연결, HTTP 응답 각각)
+const probeTimeout = 1500 * time.Millisecond
+
+// HTML에서 <title>을 찾을 때 읽는 최대 크기
+const probeBodyLimit = 64 * 1024
+
+var titlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// ProbeResult --probe 결과
+type ProbeResult struct {
+	Connected bool   `json:"connected"`
+	Status    int    `json:"status,omitempty"` // HTTP 상태 코드 (HTTP가 아니면 0)
+	Server    string `json:"server,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Location  string `json:"location,omitempty"` // 리다이렉트 대상
+	Error     string `json:"error,omitempty"`
+}
+
+// probePorts 루프백에서 접근 가능한 TCP 리스너에 동시에 연결해 봅니다.
+func probePorts(ports []PortInfo) {
+	var wg sync.WaitGroup
+	for i := range ports {
+		p := &ports[i]
+		if p.Protocol != "TCP" || p.State != "LISTEN" {
+			continue
+		}
+		if !proc.IsLoopback(p.LocalAddr) && !proc.IsWildcard(p.LocalAddr) {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.Probe = probe(probeHost(*p), p.Port)
+		}()
+	}
+	wg.Wait()
+}
+
+func probeHost(p PortInfo) string {
+	if proc.IsWildcard(p.LocalAddr) {
+		if p.Family == "IPv6" {
+			return "::1"
+		}
+		return "127.0.0.1"
+	}
+	return p.LocalAddr
+}
+
+// probe TCP 연결 후 HTTP GET /을 보내 상태 코드, Server 헤더, <title>을 읽습니다.
+func probe(host string, port int) *ProbeResult {
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	conn, err := net.DialTimeout("tcp", addr, probeTimeout)
+	if err != nil {
+		return &ProbeResult{Error: "연결 실패"}
+	}
+	conn.Close()
+	result := &ProbeResult{Connected: true}
+
+	client := &http.Client{
+		Timeout: probeTimeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Get("http://" + addr + "/")
+	if err != nil {
+		return result // HTTP가 아닌 서비스 (DB 등)
+	}
+	defer resp.Body.Close()
+
+	result.Status = resp.StatusCode
+	result.Server = resp.Header.Get("Server")
+	result.Location = resp.Header.Get("Location")
+
+	if strings.Contains(resp.Header.Get("Content-Type"), "html") {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, probeBodyLimit))
+		if m := titlePattern.FindSubmatch(body); m != nil {
+			result.Title = strings.Join(strings.Fields(html.UnescapeString(string(m[1]))), " ")
+		}
+	}
+	return result
+}
+
+// formatProbe "200 nginx "Admin"", "302 → /login", "TCP", "연결 실패"
+func formatProbe(r *ProbeResult) string {
+	switch {
+	case r == nil:
+		return ""
+	case !r.Connected:
+		return common.Red + r.Error + common.Reset
+	case r.Status == 0:
+		return "TCP"
+	}
+
+	color := common.Green
+	if r.Status >= 400 {
+		color = common.Yellow
+	}
+	s := fmt.Sprintf("%s%d%s", color, r.Status, common.Reset)
+	if r.Server != "" {
+		s += " " + r.Server
+	}
+	if r.Title != "" {
+		s += fmt.Sprintf(" %q", clip(r.Title, 40))
+	}
+	if r.Location != "" {
+		s += " → " + r.Location
+	}
+	return s
+}
+
+// clip 한 줄에 들어가도록 자릅니다 (ANSI 코드 없는 문자열).
+func clip(s string, cols int) string {
+	if cols <= 1 || utf8.RuneCountInString(s) <= cols {
+		return s
+	}
+	return string([]rune(s)[:cols-1]) + "…"
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/useful-go/pkg/common"
+	"github.com/useful-go/pkg/proc"
+)
+
+// runServe 포트 목록을 JSON(/ports)과 Prometheus 텍스트(/metrics)로 제공합니다.
+// 요청마다 새로 조회하므로 별도 갱신 주기가 없습니다.
+func runServe(addr string, filter PortFilter, services map[string]string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ports", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(getPortList(filter, services))
+	})
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		writeMetrics(w, getPortList(filter, services))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, "lsport serve: /ports (JSON), /metrics (Prometheus)")
+	})
+
+	common.Info("http://%s/ports, http://%s/metrics 에서 제공 중 (Ctrl-C로 종료)", addr, addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		common.Fatal("서버 시작 실패: %v", err)
+	}
+}
+
+// writeMetrics Prometheus 텍스트 형식으로 출력합니다.
+func writeMetrics(w io.Writer, ports []PortInfo) {
+	// 프로세스별 리스너 수
+	type owner struct{ pid, command, user string }
+	listeners := make(map[owner]int)
+	for _, p := range ports {
+		if isListener(p) {
+			listeners[owner{p.PID, p.Command, p.User}]++
+		}
+	}
+	owners := make([]owner, 0, len(listeners))
+	for o := range listeners {
+		owners = append(owners, o)
+	}
+	sort.Slice(owners, func(i, j int) bool { return owners[i].pid < owners[j].pid })
+
+	fmt.Fprintln(w, "# HELP lsport_listeners Number of listening sockets per process.")
+	fmt.Fprintln(w, "# TYPE lsport_listeners gauge")
+	for _, o := range owners {
+		fmt.Fprintf(w, "lsport_listeners{%s} %d\n", labels("pid", o.pid, "command", o.command, "user", o.user), listeners[o])
+	}
+
+	// 상태별 TCP 연결 수 (소유자 없는 TIME_WAIT 포함)
+	states := make(map[string]int)
+	if conns, err := proc.TCPTable(); err == nil {
+		for _, c := range conns {
+			states[c.State]++
+		}
+	}
+	fmt.Fprintln(w, "# HELP lsport_tcp_connections Number of TCP sockets per state.")
+	fmt.Fprintln(w, "# TYPE lsport_tcp_connections gauge")
+	for _, state := range sortedKeys(states) {
+		fmt.Fprintf(w, "lsport_tcp_connections{%s} %d\n", labels("state", state), states[state])
+	}
+
+	// 리스너별 연결 수와 점유 프로세스의 CPU/메모리
+	fmt.Fprintln(w, "# HELP lsport_port_connections Accepted connections per listening port and state.")
+	fmt.Fprintln(w, "# TYPE lsport_port_connections gauge")
+	for _, p := range ports {
+		if p.Protocol != "TCP" || p.State != "LISTEN" {
+			continue
+		}
+		for _, c := range []struct {
+			state string
+			n     int
+		}{{"ESTABLISHED", p.Established}, {"CLOSE_WAIT", p.CloseWait}, {"TIME_WAIT", p.TimeWait}} {
+			fmt.Fprintf(w, "lsport_port_connections{%s} %d\n", portLabels(p, "state", c.state), c.n)
+		}
+	}
+
+	fmt.Fprintln(w, "# HELP lsport_port_cpu_percent CPU usage of the process owning the port.")
+	fmt.Fprintln(w, "# TYPE lsport_port_cpu_percent gauge")
+	for _, p := range ports {
+		if isListener(p) && parseFloat(p.CPU) >= 0 {
+			fmt.Fprintf(w, "lsport_port_cpu_percent{%s} %s\n", portLabels(p), p.CPU)
+		}
+	}
+
+	fmt.Fprintln(w, "# HELP lsport_port_memory_percent Memory usage of the process owning the port.")
+	fmt.Fprintln(w, "# TYPE lsport_port_memory_percent gauge")
+	for _, p := range ports {
+		if isListener(p) && parseFloat(p.Mem) >= 0 {
+			fmt.Fprintf(w, "lsport_port_memory_percent{%s} %s\n", portLabels(p), p.Mem)
+		}
+	}
+}
+
+func portLabels(p PortInfo, extra ...string) string {
+	kv := []string{
+		"port", strconv.Itoa(p.Port),
+		"proto", strings.ToLower(protoLabel(p)),
+		"addr", p.LocalAddr,
+		"pid", p.PID,
+		"command", p.Command,
+		"service", p.Service,
+	}
+	return labels(append(kv, extra...)...)
+}
+
+// labels key, value 쌍을 Prometheus 레이블 문자열로 만듭니다.
+func labels(kv ...string) string {
+	var parts []string
+	for i := 0; i+1 < len(kv); i += 2 {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, kv[i], escapeLabel(kv[i+1])))
+	}
+	return strings.Join(parts, ",")
+}
+
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/useful-go/pkg/common"
+	"github.com/useful-go/pkg/config"
+)
+
+// wellKnownServices 자주 쓰는 포트 → 서비스 이름 (IANA 등록 포트 일부와 개발 도구 기본 포트)
+var wellKnownServices = map[int]string{
+	20: "ftp-data", 21: "ftp", 22: "ssh", 23: "telnet", 25: "smtp", 53: "dns",
+	67: "dhcp", 68: "dhcp", 69: "tftp", 80: "http", 110: "pop3", 111: "rpcbind",
+	123: "ntp", 137: "netbios", 138: "netbios", 139: "netbios", 143: "imap",
+	161: "snmp", 389: "ldap", 443: "https", 445: "smb", 465: "smtps", 514: "syslog",
+	587: "submission", 631: "ipp", 636: "ldaps", 853: "dns-tls", 873: "rsync",
+	993: "imaps", 995: "pop3s", 1080: "socks", 1433: "mssql", 1521: "oracle",
+	1883: "mqtt", 2049: "nfs", 2181: "zookeeper", 2375: "docker", 2376: "docker-tls",
+	2379: "etcd", 2380: "etcd-peer", 3306: "mysql", 3389: "rdp", 4222: "nats",
+	4369: "epmd", 5000: "flask", 5037: "adb", 5353: "mdns", 5432: "postgres",
+	5601: "kibana", 5672: "amqp", 5900: "vnc", 6379: "redis", 6443: "kube-api",
+	6831: "jaeger", 8086: "influxdb", 8125: "statsd", 8200: "vault", 8300: "consul",
+	8500: "consul", 8883: "mqtts", 9000: "minio", 9042: "cassandra", 9090: "prometheus",
+	9092: "kafka", 9093: "alertmanager", 9100: "node-exporter", 9200: "elasticsearch",
+	9300: "elasticsearch", 9411: "zipkin", 11211: "memcached", 15672: "rabbitmq-ui",
+	27017: "mongodb", 50051: "grpc",
+}
+
+// devServer 명령줄로 알아보는 개발 서버
+type devServer struct {
+	name     string
+	commands []string // 실행 파일 또는 스크립트(argv[0], argv[1])의 이름
+	patterns []string // 명령줄에 하나라도 포함되면 일치
+}
+
+// 위에서부터 먼저 일치하는 항목을 사용합니다 (next dev가 내부적으로 webpack을 쓰는 경우 등).
+var devServers = []devServer{
+	{"vite", []string{"vite", "vite.js"}, nil},
+	{"next dev", nil, []string{"next dev", "next-server"}},
+	{"webpack-dev-server", []string{"webpack-dev-server"}, []string{"webpack serve"}},
+	{"spring-boot", nil, []string{"spring-boot", "org.springframework.boot"}},
+	{"uvicorn", []string{"uvicorn"}, []string{"-m uvicorn"}},
+	{"go run", nil, []string{"/go-build"}},
+}
+
+// serviceConfig ~/.config/useful/lsport.json
+//
+//	{"services": {"8080": "my-api", "5353/udp": "mdns"}}
+type serviceConfig struct {
+	Services map[string]string `json:"services"`
+}
+
+// loadServices lsport.json의 사용자 서비스 이름을 읽습니다. 설정이 잘못되면 경고하고 내장 목록만 사용합니다.
+// --watch, serve에서 갱신할 때마다 경고하지 않도록 main에서 한 번만 읽습니다.
+func loadServices() map[string]string {
+	var cfg serviceConfig
+	if err := config.Load("lsport", &cfg); err != nil {
+		common.Warning("%v", err)
+	}
+	return cfg.Services
+}
+
+// annotateServices 개발 서버(명령줄) 또는 포트 번호로 SERVICE를 채웁니다.
+// 리스너는 로컬 포트, 클라이언트 연결은 원격 포트로 찾습니다.
+func annotateServices(ports []PortInfo, services map[string]string) {
+	for i := range ports {
+		p := &ports[i]
+		if isListener(*p) {
+			if name := detectDevServer(p.Args); name != "" {
+				p.Service = name
+				continue
+			}
+			p.Service = lookupService(services, p.Port, p.Protocol)
+		} else if p.RemotePort > 0 {
+			if name := lookupService(services, p.RemotePort, p.Protocol); name != "" {
+				p.Service = "→ " + name
+			}
+		}
+	}
+}
+
+func detectDevServer(cmdline string) string {
+	if cmdline == "" {
+		return ""
+	}
+	fields := strings.Fields(cmdline)
+	var names []string
+	for i := 0; i < len(fields) && i < 2; i++ {
+		names = append(names, filepath.Base(fields[i]))
+	}
+
+	for _, d := range devServers {
+		for _, c := range d.commands {
+			for _, n := range names {
+				if n == c {
+					return d.name
+				}
+			}
+		}
+		for _, pat := range d.patterns {
+			if strings.Contains(cmdline, pat) {
+				return d.name
+			}
+		}
+	}
+	return ""
+}
+
+// lookupService 사용자 설정("port/proto", "port")을 먼저 찾고, 없으면 내장 목록을 사용합니다.
+func lookupService(custom map[string]string, port int, protocol string) string {
+	key := strconv.Itoa(port)
+	proto := strings.ToLower(strings.TrimSuffix(protocol, "6"))
+	if name, ok := custom[key+"/"+proto]; ok {
+		return name
+	}
+	if name, ok := custom[key]; ok {
+		return name
+	}
+	return wellKnownServices[port]
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/useful-go/pkg/common"
+	"github.com/useful-go/pkg/config"
+)
+
+// Snapshot lsport snapshot save로 저장하는 파일 형식
+type Snapshot struct {
+	Name    string     `json:"name"`
+	Created time.Time  `json:"created"`
+	Ports   []PortInfo `json:"ports"`
+}
+
+// portChange diff 결과 한 항목. 같은 리스너를 여러 PID가 가질 수 있어(nginx 워커, SO_REUSEPORT) 소유 행 전체를 담습니다.
+type portChange struct {
+	Kind   string // opened, closed, changed
+	Before []PortInfo
+	After  []PortInfo
+}
+
+// snapshotPath 이름을 스냅샷 파일 경로로 바꿉니다.
+// 경로 구분자가 있거나 .json으로 끝나면 그대로 사용합니다 (CI 아티팩트 등).
+func snapshotPath(name string) string {
+	if strings.ContainsRune(name, os.PathSeparator) || strings.HasSuffix(name, ".json") {
+		return name
+	}
+	return filepath.Join(config.Dir(), "lsport", "snapshots", name+".json")
+}
+
+func saveSnapshot(name string, ports []PortInfo) (string, error) {
+	path := snapshotPath(name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(Snapshot{Name: name, Created: time.Now(), Ports: ports}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return path, os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+func loadSnapshot(name string) (Snapshot, error) {
+	var snap Snapshot
+	path := snapshotPath(name)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return snap, fmt.Errorf("스냅샷이 없습니다: %s (lsport snapshot save %s)", path, name)
+		}
+		return snap, err
+	}
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return snap, fmt.Errorf("%s 파싱 실패: %v", path, err)
+	}
+	return snap, nil
+}
+
+// listenerKey diff에서 같은 리스너로 보는 기준 (PID는 제외해 재시작을 소유자 변경으로 구분)
+func listenerKey(p PortInfo) string {
+	return fmt.Sprintf("%d-%s-%s", p.Port, p.Protocol, p.LocalAddr)
+}
+
+// listenerOwners 리스너 키별 소유 행 (PID 순)
+func listenerOwners(ports []PortInfo) map[string][]PortInfo {
+	owners := make(map[string][]PortInfo)
+	for _, p := range ports {
+		if isListener(p) {
+			key := listenerKey(p)
+			owners[key] = append(owners[key], p)
+		}
+	}
+	for _, list := range owners {
+		sortPorts(list, "pid", false)
+	}
+	return owners
+}
+
+// ownerSet 소유자 비교 기준 (PID, 명령, 컨테이너의 집합)
+func ownerSet(list []PortInfo) string {
+	var ids []string
+	for _, p := range list {
+		id := fmt.Sprintf("%s/%s/%s", p.PID, p.Command, p.Container)
+		if !containsString(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+// diffPorts 리스너만 비교합니다. 클라이언트 연결은 임시 포트라 매번 달라지므로 제외합니다.
+func diffPorts(before, after []PortInfo) []portChange {
+	old := listenerOwners(before)
+	cur := listenerOwners(after)
+
+	var changes []portChange
+	for key, now := range cur {
+		prev, ok := old[key]
+		switch {
+		case !ok:
+			changes = append(changes, portChange{Kind: "opened", After: now})
+		case ownerSet(prev) != ownerSet(now):
+			changes = append(changes, portChange{Kind: "changed", Before: prev, After: now})
+		}
+	}
+	for key, prev := range old {
+		if _, ok := cur[key]; !ok {
+			changes = append(changes, portChange{Kind: "closed", Before: prev})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changeRow(changes[i]), changeRow(changes[j])
+		if a.Port != b.Port {
+			return a.Port < b.Port
+		}
+		return listenerKey(a) < listenerKey(b)
+	})
+	return changes
+}
+
+// changeRow 변경 항목의 포트, 주소를 표시할 대표 행
+func changeRow(c portChange) PortInfo {
+	if c.Kind == "closed" {
+		return c.Before[0]
+	}
+	return c.After[0]
+}
+
+func printDiff(snap Snapshot, changes []portChange) {
+	common.Header("스냅샷 %s (%s) 이후 변경된 리스너", snap.Name, snap.Created.Format("2006-01-02 15:04:05"))
+	fmt.Println()
+
+	for _, c := range changes {
+		r := changeRow(c)
+		addr := fmt.Sprintf("%-7d %-6s %-22s", r.Port, protoLabel(r), formatAddr(r.LocalAddr, r.Port))
+		switch c.Kind {
+		case "opened":
+			fmt.Printf("%s+ %s %s%s\n", common.Green, addr, ownersLabel(c.After), common.Reset)
+		case "closed":
+			fmt.Printf("%s- %s %s%s\n", common.Red, addr, ownersLabel(c.Before), common.Reset)
+		case "changed":
+			fmt.Printf("%s~ %s %s → %s%s\n", common.Yellow, addr, ownersLabel(c.Before), ownersLabel(c.After), common.Reset)
+		}
+	}
+
+	fmt.Println()
+	common.Info("총 %d개 변경", len(changes))
+}
+
+// ownersLabel "nginx (PID 10, 11)" 형식으로 소유자를 표시합니다.
+func ownersLabel(list []PortInfo) string {
+	var names, pids []string
+	for _, p := range list {
+		if name := ownerLabel(p); !containsString(names, name) {
+			names = append(names, name)
+		}
+		if !containsString(pids, p.PID) {
+			pids = append(pids, p.PID)
+		}
+	}
+	return fmt.Sprintf("%s (PID %s)", strings.Join(names, ", "), strings.Join(pids, ", "))
+}
+
+func ownerLabel(p PortInfo) string {
+	if p.Container != "" {
+		return fmt.Sprintf("%s [%s]", p.Command, p.Container)
+	}
+	return p.Command
+}
+
+// runSnapshot lsport snapshot save <name>
+func runSnapshot(args []string, ports []PortInfo) {
+	if len(args) < 2 || args[0] != "save" {
+		common.Fatal("사용법: lsport snapshot save <name>")
+	}
+
+	path, err := saveSnapshot(args[1], ports)
+	if err != nil {
+		common.Fatal("스냅샷 저장 실패: %v", err)
+	}
+	common.Success("%d개 포트를 스냅샷 %s에 저장했습니다 (%s)", len(ports), args[1], path)
+}
+
+// runDiff lsport diff <name>. 변경이 있으면 종료 코드 1, 오류는 2입니다.
+func runDiff(args []string, ports []PortInfo, jsonOut bool) {
+	if len(args) < 1 {
+		common.Error("사용법: lsport diff <name>")
+		os.Exit(2)
+	}
+
+	snap, err := loadSnapshot(args[0])
+	if err != nil {
+		common.Error("%v", err)
+		os.Exit(2)
+	}
+
+	changes := diffPorts(snap.Ports, ports)
+	if jsonOut {
+		printJSON(diffJSON(changes))
+	} else if len(changes) == 0 {
+		common.Success("스냅샷 %s 이후 변경된 리스너가 없습니다", snap.Name)
+	} else {
+		printDiff(snap, changes)
+	}
+
+	if len(changes) > 0 {
+		os.Exit(1)
+	}
+}
+
+// diffJSON --json 출력 형식 ({"kind": "opened", "after": [{...}]})
+func diffJSON(changes []portChange) []map[string]interface{} {
+	result := []map[string]interface{}{}
+	for _, c := range changes {
+		item := map[string]interface{}{"kind": c.Kind}
+		if c.Kind != "opened" {
+			item["before"] = c.Before
+		}
+		if c.Kind != "closed" {
+			item["after"] = c.After
+		}
+		result = append(result, item)
+	}
+	return result
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/useful-go/pkg/common"
+	"github.com/useful-go/pkg/proc"
+)
+
+// UnixInfo --unix 한 행 (경로에 bind된 Unix 도메인 소켓)
+type UnixInfo struct {
+	Path        string `json:"path"`
+	Type        string `json:"type"`
+	State       string `json:"state"`
+	PID         string `json:"pid"`
+	Command     string `json:"command"`
+	User        string `json:"user"`
+	Connections int    `json:"connections"` // 리스너가 수락한 연결 수 (Linux)
+}
+
+// getUnixList 리스너/bind된 소켓만 행으로 만들고, 같은 경로의 수락된 연결은 Connections로 합칩니다.
+func getUnixList(filter PortFilter) ([]UnixInfo, error) {
+	sockets, err := proc.UnixSockets()
+	if err != nil {
+		return nil, err
+	}
+
+	conns := make(map[string]int)
+	for _, s := range sockets {
+		if !s.Listening && s.State == "CONNECTED" {
+			conns[s.Path]++
+		}
+	}
+
+	list := []UnixInfo{}
+	seen := make(map[string]bool)
+	for _, s := range sockets {
+		if !s.Listening {
+			continue
+		}
+		if filter.User != "" && s.User != filter.User {
+			continue
+		}
+		if filter.Command != nil && !filter.Command.MatchString(s.Command) {
+			continue
+		}
+
+		key := s.Path + "-" + s.PID
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		list = append(list, UnixInfo{
+			Path:        s.Path,
+			Type:        s.Type,
+			State:       s.State,
+			PID:         s.PID,
+			Command:     s.Command,
+			User:        s.User,
+			Connections: conns[s.Path],
+		})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Path < list[j].Path
+	})
+	return list, nil
+}
+
+func printUnixTable(list []UnixInfo) {
+	common.Header("Unix 도메인 소켓 목록")
+	fmt.Println()
+
+	fmt.Printf("%s%-50s %-10s %-12s %-8s %-18s %-12s %s%s\n",
+		common.Bold, "PATH", "TYPE", "STATE", "PID", "COMMAND", "USER", "CONN", common.Reset)
+	fmt.Println(strings.Repeat("─", 120))
+
+	for _, u := range list {
+		pid, command := u.PID, u.Command
+		if pid == "" {
+			pid, command = "-", "-"
+		}
+		fmt.Printf("%-50s %-10s %s%-12s%s %-8s %-18s %-12s %d\n",
+			u.Path, valueOr(u.Type, "-"), getStateColor(u.State), u.State, common.Reset,
+			pid, truncate(command, 18), truncate(valueOr(u.User, "-"), 12), u.Connections)
+	}
+
+	fmt.Println()
+	common.Info("총 %d개 소켓", len(list))
+}
+
+func valueOr(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,383 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/useful-go/pkg/common"
+	"github.com/useful-go/pkg/docker"
+	"github.com/useful-go/pkg/proc"
+	"github.com/useful-go/pkg/ui"
+)
+
+// 새로 열린/닫힌 포트를 강조하는 갱신 횟수
+const highlightRefreshes = 3
+
+// watchRow 화면에 표시할 한 줄 (닫힌 포트도 잠시 유지)
+type watchRow struct {
+	PortInfo
+	opened bool
+	closed bool
+}
+
+type closedPort struct {
+	PortInfo
+	left int
+}
+
+type watchState struct {
+	fetch       func() []PortInfo
+	interval    time.Duration
+	interactive bool // -i: 상세 정보 창 표시
+
+	ports  []PortInfo
+	known  map[string]bool
+	opened map[string]int        // 키 → 남은 강조 횟수
+	closed map[string]closedPort // 키 → 닫힌 포트와 남은 강조 횟수
+
+	sortIdx  int
+	filter   string
+	selected int
+	mode     string   // "", "filter", "confirm"
+	target   PortInfo // 종료 확인 중인 포트 (k를 누른 시점의 행)
+	message  string
+	started  bool
+}
+
+// runWatch 포트 목록을 주기적으로 다시 그립니다. q 또는 Ctrl-C로 종료합니다.
+// interactive면 선택한 포트의 상세 정보 창을 함께 그립니다 (lsport -i).
+func runWatch(interval time.Duration, sortKey string, interactive bool, fetch func() []PortInfo) {
+	restore, err := ui.RawMode()
+	if err != nil {
+		common.Fatal("%v", err)
+	}
+	defer func() {
+		restore()
+		fmt.Println()
+	}()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sig)
+
+	st := &watchState{
+		fetch:       fetch,
+		interval:    interval,
+		interactive: interactive,
+		known:       make(map[string]bool),
+		opened:      make(map[string]int),
+		closed:      make(map[string]closedPort),
+	}
+	for i, k := range sortKeys {
+		if k == sortKey {
+			st.sortIdx = i
+		}
+	}
+
+	keys := ui.ReadKeys()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	st.refresh()
+	st.render()
+
+	for {
+		select {
+		case <-ticker.C:
+			st.refresh()
+		case k, ok := <-keys:
+			if !ok || st.handleKey(k) {
+				return
+			}
+		case <-sig:
+			return
+		}
+		st.render()
+	}
+}
+
+func portKey(p PortInfo) string {
+	return fmt.Sprintf("%d-%s-%s-%s", p.Port, p.Protocol, p.LocalAddr, p.PID)
+}
+
+// refresh 포트 목록을 다시 조회하고 이전 목록과 비교해 열린/닫힌 포트를 기록합니다.
+func (st *watchState) refresh() {
+	ports := st.fetch()
+
+	for key, n := range st.opened {
+		if n <= 1 {
+			delete(st.opened, key)
+		} else {
+			st.opened[key] = n - 1
+		}
+	}
+	for key, c := range st.closed {
+		if c.left <= 1 {
+			delete(st.closed, key)
+		} else {
+			c.left--
+			st.closed[key] = c
+		}
+	}
+
+	current := make(map[string]bool)
+	for _, p := range ports {
+		key := portKey(p)
+		current[key] = true
+		if st.started && !st.known[key] {
+			st.opened[key] = highlightRefreshes
+		}
+		delete(st.closed, key)
+	}
+	for _, p := range st.ports {
+		key := portKey(p)
+		if !current[key] {
+			st.closed[key] = closedPort{PortInfo: p, left: highlightRefreshes}
+		}
+	}
+
+	st.ports = ports
+	st.known = current
+	st.started = true
+}
+
+// rows 필터와 정렬을 적용한 표시 목록 (닫힌 포트 포함)
+func (st *watchState) rows() []watchRow {
+	all := make([]PortInfo, 0, len(st.ports)+len(st.closed))
+	all = append(all, st.ports...)
+	for _, c := range st.closed {
+		all = append(all, c.PortInfo)
+	}
+	sortPorts(all, sortKeys[st.sortIdx], false)
+
+	var rows []watchRow
+	for _, p := range all {
+		if !st.matches(p) {
+			continue
+		}
+		key := portKey(p)
+		_, closed := st.closed[key]
+		rows = append(rows, watchRow{PortInfo: p, opened: st.opened[key] > 0, closed: closed})
+	}
+	return rows
+}
+
+func (st *watchState) matches(p PortInfo) bool {
+	if st.filter == "" {
+		return true
+	}
+	f := strings.ToLower(st.filter)
+	return fuzzyMatch(f, p.Command) ||
+		fuzzyMatch(f, p.Service) ||
+		strings.Contains(strconv.Itoa(p.Port), f) ||
+		p.PID == st.filter ||
+		strings.Contains(strings.ToLower(p.User), f)
+}
+
+// handleKey 키 입력을 처리합니다. 종료해야 하면 true를 반환합니다.
+func (st *watchState) handleKey(k ui.Key) bool {
+	switch st.mode {
+	case "filter":
+		switch k.Name {
+		case "enter":
+			st.mode = ""
+		case "esc":
+			st.filter = ""
+			st.mode = ""
+		case "backspace":
+			if r := []rune(st.filter); len(r) > 0 {
+				st.filter = string(r[:len(r)-1])
+			}
+		default:
+			if k.Rune != 0 {
+				st.filter += string(k.Rune)
+			}
+		}
+		st.selected = 0
+		return false
+
+	case "confirm":
+		st.mode = ""
+		if k.Rune == 'y' || k.Rune == 'Y' {
+			// 확인하는 동안 목록이 갱신되어 선택 행이 바뀌었을 수 있으므로
+			// 확인 창에 표시한 포트가 아직 같은 프로세스에 열려 있는지 다시 확인
+			st.refresh()
+			if !st.stillOpen(st.target) {
+				st.message = fmt.Sprintf("PID %s의 포트 %d가 이미 닫혀 종료하지 않았습니다", st.target.PID, st.target.Port)
+				return false
+			}
+			st.message = "종료 중..."
+			st.render()
+			st.message = killPort(st.target)
+			st.refresh()
+		} else {
+			st.message = "취소되었습니다"
+		}
+		return false
+	}
+
+	st.message = ""
+	rows := st.rows()
+
+	switch {
+	case k.Rune == 'q' || k.Rune == 'Q':
+		return true
+	case k.Name == "up":
+		if st.selected > 0 {
+			st.selected--
+		}
+	case k.Name == "down":
+		if st.selected < len(rows)-1 {
+			st.selected++
+		}
+	case k.Rune == 's':
+		st.sortIdx = (st.sortIdx + 1) % len(sortKeys)
+	case k.Rune == '/':
+		st.mode = "filter"
+	case k.Name == "esc":
+		st.filter = ""
+	case k.Rune == 'k':
+		if st.selected >= len(rows) {
+			return false
+		}
+		r := rows[st.selected]
+		if r.closed {
+			st.message = fmt.Sprintf("포트 %d는 이미 닫혔습니다", r.Port)
+			return false
+		}
+		st.target = r.PortInfo
+		st.mode = "confirm"
+	}
+	return false
+}
+
+// stillOpen 현재 목록에 같은 PID가 같은 포트를 아직 점유하고 있는지 확인합니다.
+func (st *watchState) stillOpen(t PortInfo) bool {
+	for _, p := range st.ports {
+		if p.PID == t.PID && p.Port == t.Port && p.Protocol == t.Protocol && p.Container == t.Container {
+			return true
+		}
+	}
+	return false
+}
+
+// killPort portkill과 같은 경로로 포트 점유 프로세스를 종료합니다.
+// Docker 게시 포트는 프록시 대신 컨테이너를 중지합니다.
+func killPort(p PortInfo) string {
+	if p.Container != "" {
+		if err := docker.Stop(p.Container); err != nil {
+			return fmt.Sprintf("컨테이너 %s 중지 실패: %v", p.Container, err)
+		}
+		return fmt.Sprintf("컨테이너 %s 중지 완료", p.Container)
+	}
+
+	policy, err := proc.LoadPolicy()
+	if err != nil {
+		return fmt.Sprintf("보호 정책 설정을 읽지 못했습니다: %v", err)
+	}
+	forced, err := proc.Kill(p.PID, policy, proc.DefaultGrace)
+	if err != nil {
+		return fmt.Sprintf("PID %s 종료 실패: %v", p.PID, err)
+	}
+	if forced {
+		return fmt.Sprintf("PID %s 종료 완료 (SIGKILL)", p.PID)
+	}
+	return fmt.Sprintf("PID %s 종료 완료", p.PID)
+}
+
+func (st *watchState) render() {
+	rows := st.rows()
+	if st.selected >= len(rows) {
+		st.selected = len(rows) - 1
+	}
+	if st.selected < 0 {
+		st.selected = 0
+	}
+
+	termRows, termCols := ui.TermSize()
+	visible := termRows - 7
+	if st.interactive {
+		visible -= detailHeight
+	}
+	if visible < 1 {
+		visible = 1
+	}
+	offset := 0
+	if st.selected >= visible {
+		offset = st.selected - visible + 1
+	}
+
+	var b strings.Builder
+	b.WriteString(ui.ClearScreen)
+
+	mode := "--watch"
+	if st.interactive {
+		mode = "-i"
+	}
+	title := fmt.Sprintf("lsport %s  갱신 %s  정렬: %s", mode, st.interval, sortKeys[st.sortIdx])
+	if st.filter != "" {
+		title += fmt.Sprintf("  필터: %s", st.filter)
+	}
+	fmt.Fprintf(&b, "%s%s%s  %s\n", common.Bold, title, common.Reset, time.Now().Format("15:04:05"))
+	b.WriteString("↑↓ 선택  s 정렬  / 필터  esc 필터 해제  k 종료  q 나가기\n\n")
+
+	fmt.Fprintf(&b, "%s%-7s %-6s %-22s %-8s %-18s %-7s %-7s %-12s %-11s %s%s\n",
+		common.Bold, "PORT", "PROTO", "ADDRESS", "PID", "COMMAND", "CPU%", "MEM%", "USER", "STATE", "CONTAINER", common.Reset)
+	b.WriteString(strings.Repeat("─", 110) + "\n")
+
+	for i := offset; i < len(rows) && i < offset+visible; i++ {
+		r := rows[i]
+		line := fmt.Sprintf("%-7d %-6s %-22s %-8s %-18s %-7s %-7s %-12s %-11s %s",
+			r.Port, protoLabel(r.PortInfo), formatAddr(r.LocalAddr, r.Port), r.PID, truncate(r.Command, 18), r.CPU, r.Mem,
+			truncate(r.User, 12), r.State, r.Container)
+
+		color := ""
+		switch {
+		case r.closed:
+			color = common.Red
+		case r.opened:
+			color = common.Green
+		}
+		if i == st.selected {
+			color += ui.Reverse
+		}
+		fmt.Fprintf(&b, "%s%s%s\n", color, line, common.Reset)
+	}
+
+	if st.interactive {
+		for i := len(rows) - offset; i < visible; i++ {
+			b.WriteString("\n") // 상세 정보 창을 화면 아래쪽에 고정
+		}
+		if st.selected < len(rows) {
+			r := rows[st.selected]
+			renderDetail(&b, r.PortInfo, r.closed, termCols)
+		}
+	}
+
+	b.WriteString("\n")
+	switch st.mode {
+	case "filter":
+		fmt.Fprintf(&b, "필터: %s▏", st.filter)
+	case "confirm":
+		t := st.target
+		if t.Container != "" {
+			fmt.Fprintf(&b, "%s컨테이너 %s (포트 %d)를 중지하시겠습니까? (y/N)%s", common.Yellow, t.Container, t.Port, common.Reset)
+		} else {
+			fmt.Fprintf(&b, "%sPID %s (%s, 포트 %d)를 종료하시겠습니까? SIGTERM 후 %s 안에 끝나지 않으면 SIGKILL (y/N)%s",
+				common.Yellow, t.PID, t.Command, t.Port, proc.DefaultGrace, common.Reset)
+		}
+	default:
+		if st.message != "" {
+			fmt.Fprintf(&b, "%s%s%s", common.Cyan, st.message, common.Reset)
+		} else {
+			fmt.Fprintf(&b, "총 %d개 포트", len(st.ports))
+		}
+	}
+
+	fmt.Print(b.String())
+}
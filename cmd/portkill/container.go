@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/useful-go/pkg/common"
+	"github.com/useful-go/pkg/docker"
+)
+
+// ContainerTarget Docker 프록시가 대신 점유한 포트의 실제 컨테이너
+type ContainerTarget struct {
+	docker.Container
+	Proxy string // 포트를 점유한 프록시 프로세스 (docker-proxy 등)
+	Ports []int
+}
+
+// splitContainers 점유 프로세스 중 Docker 프록시를 찾아 해당 컨테이너로 바꿉니다.
+// 프록시를 죽이면 Docker 자체가 망가지므로 대신 컨테이너를 중지해야 합니다.
+func splitContainers(owners []Target) ([]Target, []ContainerTarget) {
+	var proxied bool
+	for _, t := range owners {
+		if docker.IsProxy(t.Command) {
+			proxied = true
+			break
+		}
+	}
+	if !proxied {
+		return owners, nil
+	}
+
+	published, err := docker.PublishedPorts()
+	if err != nil {
+		common.Warning("Docker 컨테이너 조회 실패: %v", err)
+		return owners, nil
+	}
+
+	var rest []Target
+	var containers []ContainerTarget
+	index := make(map[string]int)
+
+	for _, t := range owners {
+		if !docker.IsProxy(t.Command) {
+			rest = append(rest, t)
+			continue
+		}
+
+		matched := false
+		for _, port := range t.Ports {
+			for _, proto := range t.Protocols {
+				c, ok := published[docker.Key(port, proto)]
+				if !ok {
+					continue
+				}
+				matched = true
+				i, exists := index[c.Name]
+				if !exists {
+					i = len(containers)
+					index[c.Name] = i
+					containers = append(containers, ContainerTarget{Container: c, Proxy: t.Command})
+				}
+				if !containsInt(containers[i].Ports, port) {
+					containers[i].Ports = append(containers[i].Ports, port)
+				}
+			}
+		}
+
+		// 컨테이너를 찾지 못한 프록시는 그대로 두어 보호 정책이 판단하게 함
+		if !matched {
+			rest = append(rest, t)
+		}
+	}
+
+	return rest, containers
+}
+
+func printContainerTable(containers []ContainerTarget) {
+	fmt.Printf("%s%-14s %-24s %-30s %-20s %s%s\n",
+		common.Bold, "CONTAINER ID", "NAME", "IMAGE", "PROXY", "PORTS", common.Reset)
+	fmt.Println(strings.Repeat("─", 100))
+
+	for _, c := range containers {
+		fmt.Printf("%-14s %-24s %-30s %-20s %s\n",
+			truncate(c.ID, 14), truncate(c.Name, 24), truncate(c.Image, 30), truncate(c.Proxy, 20), joinPorts(c.Ports))
+	}
+}
+
+func stopContainer(c ContainerTarget) {
+	if err := docker.Stop(c.Name); err != nil {
+		common.Error("컨테이너 %s 중지 실패: %v", c.Name, err)
+		return
+	}
+	common.Success("컨테이너 %s 중지 완료", c.Name)
+}
@@ -1,90 +1,582 @@
 package main
 
 import (
-	"bufio"
+	"flag"
 	"fmt"
 	"os"
-	"os/exec"
+	"os/signal"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/useful-go/pkg/common"
+	"github.com/useful-go/pkg/config"
+	"github.com/useful-go/pkg/proc"
+	"github.com/useful-go/pkg/ui"
 )
 
+// Target 종료 대상 프로세스 (여러 포트를 점유해도 PID당 하나)
+type Target struct {
+	PID       string
+	Command   string
+	User      string
+	Ports     []int
+	Protocols []string
+	States    []string // LISTEN, ESTABLISHED 등
+	Args      string   // 전체 명령줄 (--name/--cmd 모드에서만)
+}
+
 func main() {
-	if len(os.Args) < 2 {
+	protoFlag := flag.String("proto", "", "프로토콜 필터 (tcp|udp)")
+	includeClients := flag.Bool("include-clients", false, "포트에 연결된 클라이언트 프로세스도 종료")
+	tree := flag.Bool("tree", false, "상위/하위 프로세스 트리를 표시하고 서브트리 단위로 종료")
+	namePattern := flag.String("name", "", "프로세스 이름 정규식으로 대상 선택 (소켓을 가진 프로세스)")
+	cmdPattern := flag.String("cmd", "", "전체 명령줄 정규식으로 대상 선택 (소켓을 가진 프로세스)")
+	force := flag.Bool("force", false, "다른 사용자 프로세스 및 보호 목록의 프로세스도 종료")
+	grace := flag.Duration("grace", proc.DefaultGrace, "SIGTERM 후 SIGKILL까지 대기 시간 (0이면 즉시 SIGKILL)")
+	wait := flag.Bool("wait", false, "종료 후 포트가 해제될 때까지 대기")
+	timeout := flag.Duration("timeout", 30*time.Second, "--wait 최대 대기 시간 (초과 시 종료 코드 1)")
+	help := flag.Bool("help", false, "도움말")
+	flag.BoolVar(help, "h", false, "도움말")
+	flag.Parse()
+
+	if *help {
+		printUsage()
+		return
+	}
+
+	nameMode := *namePattern != "" || *cmdPattern != ""
+	if nameMode && flag.NArg() > 0 {
+		common.Fatal("--name/--cmd와 포트 번호는 함께 사용할 수 없습니다")
+	}
+	if !nameMode && flag.NArg() == 0 {
 		common.Error("포트 번호를 입력해주세요")
-		fmt.Println("사용법: portkill <port>")
+		fmt.Println("사용법: portkill [--proto tcp|udp] <port|start-end|p1,p2>...")
+		fmt.Println("        portkill --name <regex> | --cmd <regex>")
 		os.Exit(1)
 	}
 
-	port := os.Args[1]
-	if _, err := strconv.Atoi(port); err != nil {
-		common.Fatal("유효하지 않은 포트 번호: %s", port)
+	proto := strings.ToLower(*protoFlag)
+	if proto != "" && proto != "tcp" && proto != "udp" {
+		common.Fatal("유효하지 않은 프로토콜: %s (tcp 또는 udp)", *protoFlag)
+	}
+
+	var owners, clients []Target
+	ownerTitle := "포트를 점유(LISTEN/bind)한 프로세스:"
+
+	if nameMode {
+		nameRe, err := compilePattern(*namePattern)
+		if err != nil {
+			common.Fatal("유효하지 않은 --name 정규식: %v", err)
+		}
+		cmdRe, err := compilePattern(*cmdPattern)
+		if err != nil {
+			common.Fatal("유효하지 않은 --cmd 정규식: %v", err)
+		}
+
+		owners = findTargetsByName(nameRe, cmdRe, proto)
+		if len(owners) == 0 {
+			common.Warning("패턴과 일치하면서 소켓을 가진 프로세스가 없습니다")
+			return
+		}
+		ownerTitle = "패턴과 일치하는 프로세스 (보유 포트):"
+	} else {
+		ports, err := parsePorts(flag.Args())
+		if err != nil {
+			common.Fatal("%v", err)
+		}
+
+		owners, clients = findTargets(ports, proto)
+		if len(owners) == 0 && len(clients) == 0 {
+			common.Warning("포트 %s를 사용하는 프로세스가 없습니다", strings.Join(flag.Args(), " "))
+			return
+		}
+	}
+
+	owners, containers := splitContainers(owners)
+	if len(containers) > 0 {
+		common.Info("Docker 컨테이너가 게시한 포트 (프록시 프로세스 대신 docker stop):")
+		fmt.Println()
+		printContainerTable(containers)
+		fmt.Println()
 	}
 
-	pids := findProcessByPort(port)
-	if len(pids) == 0 {
-		common.Warning("포트 %s를 사용하는 프로세스가 없습니다", port)
+	if len(owners) > 0 {
+		common.Info(ownerTitle)
+		fmt.Println()
+		printTargetTable(owners)
+	} else if len(containers) == 0 {
+		common.Warning("포트 %s를 점유(LISTEN/bind)한 프로세스가 없습니다", strings.Join(flag.Args(), " "))
+	}
+
+	if len(clients) > 0 {
+		fmt.Println()
+		if *includeClients {
+			common.Info("연결된 클라이언트 프로세스 (함께 종료):")
+		} else {
+			common.Info("연결된 클라이언트 프로세스 (종료하지 않음, --include-clients로 포함):")
+		}
+		fmt.Println()
+		printTargetTable(clients)
+	}
+
+	targets := owners
+	if *includeClients {
+		targets = append(targets, clients...)
+	}
+	if len(targets) == 0 && len(containers) == 0 {
 		return
 	}
 
-	common.Info("포트 %s를 사용하는 프로세스:", port)
-	for _, pid := range pids {
-		showProcessInfo(pid)
+	procTree, err := proc.LoadTree()
+	if err != nil {
+		common.Fatal("프로세스 목록 조회 실패: %v", err)
 	}
 
-	fmt.Print("\n이 프로세스를 종료하시겠습니까? (y/N): ")
-	reader := bufio.NewReader(os.Stdin)
-	answer, _ := reader.ReadString('\n')
-	answer = strings.TrimSpace(strings.ToLower(answer))
+	var pids []string
+	for _, t := range targets {
+		pids = append(pids, t.PID)
+	}
+	if *tree {
+		pids = resolveTrees(procTree, targets)
+	}
 
-	if answer != "y" && answer != "yes" {
-		common.Info("취소되었습니다")
+	pids = applyPolicy(procTree, pids, *force)
+	if len(pids) == 0 && len(containers) == 0 {
+		common.Warning("종료할 수 있는 프로세스가 없습니다")
 		return
 	}
 
+	var actions []string
+	if len(pids) > 0 {
+		if *tree {
+			fmt.Printf("\n종료 순서 (하위 프로세스부터): %s\n", strings.Join(pids, " → "))
+		}
+		actions = append(actions, fmt.Sprintf("%d개 프로세스를 종료", len(pids)))
+	}
+	if len(containers) > 0 {
+		actions = append(actions, fmt.Sprintf("%d개 컨테이너를 중지(docker stop)", len(containers)))
+	}
+	message := "\n" + strings.Join(actions, "하고 ") + "하시겠습니까?"
+
+	confirm := ui.YesNoConfirmation(message)
+	if !confirm.MustConfirm() {
+		return
+	}
+
+	for _, c := range containers {
+		stopContainer(c)
+	}
+
+	release := func() {}
+	if *tree {
+		release = suspendTree(pids)
+	}
+
+	for _, pid := range pids {
+		killProcess(pid, *grace)
+	}
+	release()
+
+	if *wait {
+		if !waitPortsFree(targets, containers, proto, *timeout) {
+			os.Exit(1)
+		}
+	}
+}
+
+// waitPortsFree 종료한 프로세스/컨테이너가 점유하던 포트가 모두 해제될 때까지 기다립니다.
+func waitPortsFree(targets []Target, containers []ContainerTarget, proto string, timeout time.Duration) bool {
+	seen := make(map[int]bool)
+	var ports []int
+	collect := func(list []int) {
+		for _, p := range list {
+			if !seen[p] {
+				seen[p] = true
+				ports = append(ports, p)
+			}
+		}
+	}
+	for _, t := range targets {
+		if containsString(t.States, "LISTEN") || containsString(t.Protocols, "UDP") {
+			collect(t.Ports)
+		}
+	}
+	for _, c := range containers {
+		collect(c.Ports)
+	}
+	sort.Ints(ports)
+
+	fmt.Println()
+	ok := true
+	deadline := time.Now().Add(timeout)
+	for _, port := range ports {
+		common.Info("포트 %d 해제 대기 중...", port)
+		remaining := time.Until(deadline)
+		if remaining < 0 {
+			remaining = 0
+		}
+		if err := proc.WaitPort("localhost", port, proto, false, remaining); err != nil {
+			common.Error("%v", err)
+			ok = false
+			continue
+		}
+		common.Success("포트 %d 해제됨", port)
+	}
+	return ok
+}
+
+func printUsage() {
+	common.Header("portkill - 포트를 사용하는 프로세스 종료")
+	fmt.Println()
+	fmt.Println("사용법: portkill [options] <port|start-end|p1,p2>...")
+	fmt.Println("        portkill [options] --name <regex> | --cmd <regex>")
+	fmt.Println()
+	fmt.Println("옵션:")
+	fmt.Println("  --proto tcp|udp   프로토콜 필터")
+	fmt.Println("  --name REGEX      프로세스 이름으로 선택 (포트 대신, 소켓을 가진 프로세스만)")
+	fmt.Println("  --cmd REGEX       전체 명령줄로 선택 (예: --cmd 'vite|next dev')")
+	fmt.Println("  --include-clients 포트에 연결된 클라이언트도 종료 (기본: 점유 프로세스만)")
+	fmt.Println("  --tree            부모(nodemon, go run 등)/자식 프로세스 트리를 표시하고 선택한 서브트리 종료")
+	fmt.Println("  --force           다른 사용자 프로세스 및 보호 목록(sshd, dockerd 등)도 종료")
+	fmt.Println("  --wait            종료 후 포트가 해제될 때까지 대기 (--timeout, 기본 30s)")
+	fmt.Println("  --grace D         SIGTERM 후 SIGKILL까지 대기 시간 (기본: 3s, 0이면 즉시 SIGKILL)")
+	fmt.Println("  -h, --help        도움말")
+	fmt.Println()
+	fmt.Println("예시:")
+	fmt.Println("  portkill 8080                  # 8080번 포트")
+	fmt.Println("  portkill 3000-3010 8080 5432   # 범위 및 여러 포트")
+	fmt.Println("  portkill --proto udp 53,5353   # UDP만")
+	fmt.Println("  portkill --include-clients 5432  # 접속 중인 클라이언트 포함")
+	fmt.Println("  portkill --tree 3000           # 재시작하는 watcher까지 함께 종료")
+	fmt.Println("  portkill --name java           # 소켓을 가진 java 프로세스")
+	fmt.Println("  portkill --cmd vite            # 명령줄에 vite가 포함된 프로세스")
+	fmt.Println()
+	fmt.Println("보호 정책:")
+	fmt.Println("  PID 1, 커널 스레드, portkill을 실행한 셸은 항상 건너뜁니다.")
+	fmt.Printf("  %s 에서 명령어 이름(glob 가능)으로 allow/deny 목록을 설정할 수 있습니다.\n", config.Path("portkill"))
+	fmt.Println(`  예: {"allow": ["node"], "deny": ["postgres", "com.docker.*"]}`)
+}
+
+// applyPolicy 보호 정책에 막힌 PID를 제외하고 건너뛴 이유를 출력합니다.
+func applyPolicy(tree *proc.Tree, pids []string, force bool) []string {
+	policy, err := proc.LoadPolicy()
+	if err != nil {
+		common.Warning("보호 정책 설정을 읽지 못했습니다: %v", err)
+	}
+	policy.Force = force
+
+	var allowed []string
 	for _, pid := range pids {
-		killProcess(pid)
+		if reason, ok := policy.Check(tree, pid); !ok {
+			common.Warning("PID %s 건너뜀: %s", pid, reason)
+			continue
+		}
+		allowed = append(allowed, pid)
+	}
+	return allowed
+}
+
+// parsePorts 포트 인자(단일, 범위, 콤마 목록)를 포트 번호 목록으로 변환합니다.
+func parsePorts(args []string) ([]int, error) {
+	var ports []int
+	seen := make(map[int]bool)
+
+	add := func(p int) {
+		if !seen[p] {
+			seen[p] = true
+			ports = append(ports, p)
+		}
+	}
+
+	for _, arg := range args {
+		for _, part := range strings.Split(arg, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+
+			if lo, hi, ok := strings.Cut(part, "-"); ok {
+				start, err1 := parsePort(lo)
+				end, err2 := parsePort(hi)
+				if err1 != nil || err2 != nil || start > end {
+					return nil, fmt.Errorf("유효하지 않은 포트 범위: %s", part)
+				}
+				for p := start; p <= end; p++ {
+					add(p)
+				}
+				continue
+			}
+
+			p, err := parsePort(part)
+			if err != nil {
+				return nil, fmt.Errorf("유효하지 않은 포트 번호: %s", part)
+			}
+			add(p)
+		}
+	}
+
+	if len(ports) == 0 {
+		return nil, fmt.Errorf("포트 번호를 입력해주세요")
 	}
+	sort.Ints(ports)
+	return ports, nil
 }
 
-func findProcessByPort(port string) []string {
-	cmd := exec.Command("lsof", "-i", ":"+port, "-t")
-	output, err := cmd.Output()
+func parsePort(s string) (int, error) {
+	p, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || p < 1 || p > 65535 {
+		return 0, fmt.Errorf("유효하지 않은 포트 번호: %s", s)
+	}
+	return p, nil
+}
+
+// findTargets 요청된 포트의 점유 프로세스(owner)와 연결된 클라이언트를 PID 기준으로 모읍니다.
+// 로컬 포트가 일치하면 점유 측(LISTEN 소켓 및 수락된 연결), 원격 포트만 일치하면 클라이언트입니다.
+func findTargets(ports []int, proto string) (owners, clients []Target) {
+	var filters []string
+	if proto != "" {
+		filters = append(filters, proto)
+	}
+
+	sockets, err := proc.ListSockets(filters...)
+	if err != nil {
+		common.Error("소켓 목록 조회 실패: %v", err)
+		return nil, nil
+	}
+
+	wanted := make(map[int]bool)
+	for _, p := range ports {
+		wanted[p] = true
+	}
+
+	ownerSet := newTargetSet()
+	clientSet := newTargetSet()
+
+	for _, s := range sockets {
+		if wanted[s.Port] {
+			ownerSet.add(s, s.Port)
+		}
+	}
+	for _, s := range sockets {
+		if wanted[s.RemotePort] && !wanted[s.Port] && !ownerSet.has(s.PID) {
+			clientSet.add(s, s.RemotePort)
+		}
+	}
+
+	return ownerSet.list(), clientSet.list()
+}
+
+// findTargetsByName 소켓을 가진 프로세스 중 이름(nameRe) 또는 명령줄(cmdRe)이 일치하는 것을 찾습니다.
+// 둘 다 지정하면 모두 일치해야 합니다.
+func findTargetsByName(nameRe, cmdRe *regexp.Regexp, proto string) []Target {
+	var filters []string
+	if proto != "" {
+		filters = append(filters, proto)
+	}
+
+	sockets, err := proc.ListSockets(filters...)
 	if err != nil {
+		common.Error("소켓 목록 조회 실패: %v", err)
 		return nil
 	}
 
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	all := newTargetSet()
+	for _, s := range sockets {
+		all.add(s, s.Port)
+	}
+
+	candidates := all.list()
 	var pids []string
-	seen := make(map[string]bool)
+	for _, t := range candidates {
+		pids = append(pids, t.PID)
+	}
+	cmdLines := proc.CommandLines(pids)
 
-	for _, line := range lines {
-		pid := strings.TrimSpace(line)
-		if pid != "" && !seen[pid] {
-			seen[pid] = true
-			pids = append(pids, pid)
+	var matched []Target
+	for _, t := range candidates {
+		t.Args = cmdLines[t.PID]
+		if nameRe != nil && !nameRe.MatchString(t.Command) {
+			continue
 		}
+		if cmdRe != nil && !cmdRe.MatchString(t.Args) {
+			continue
+		}
+		matched = append(matched, t)
 	}
-	return pids
+	return matched
 }
 
-func showProcessInfo(pid string) {
-	cmd := exec.Command("ps", "-p", pid, "-o", "pid,comm,user,%cpu,%mem")
-	output, err := cmd.Output()
-	if err != nil {
-		common.Warning("PID %s 정보 조회 실패", pid)
-		return
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
 	}
-	fmt.Println(string(output))
+	return regexp.Compile(pattern)
+}
+
+// targetSet 소켓을 PID별 Target으로 합칩니다.
+type targetSet struct {
+	byPID map[string]*Target
+	order []string
+}
+
+func newTargetSet() *targetSet {
+	return &targetSet{byPID: make(map[string]*Target)}
 }
 
-func killProcess(pid string) {
-	cmd := exec.Command("kill", "-9", pid)
-	if err := cmd.Run(); err != nil {
+func (ts *targetSet) has(pid string) bool {
+	_, ok := ts.byPID[pid]
+	return ok
+}
+
+func (ts *targetSet) add(s proc.Socket, port int) {
+	t, ok := ts.byPID[s.PID]
+	if !ok {
+		t = &Target{PID: s.PID, Command: s.Command, User: s.User}
+		ts.byPID[s.PID] = t
+		ts.order = append(ts.order, s.PID)
+	}
+	if !containsInt(t.Ports, port) {
+		t.Ports = append(t.Ports, port)
+	}
+	if !containsString(t.Protocols, s.Protocol) {
+		t.Protocols = append(t.Protocols, s.Protocol)
+	}
+	if s.State != "" && !containsString(t.States, s.State) {
+		t.States = append(t.States, s.State)
+	}
+}
+
+func (ts *targetSet) list() []Target {
+	targets := make([]Target, 0, len(ts.order))
+	for _, pid := range ts.order {
+		t := ts.byPID[pid]
+		sort.Ints(t.Ports)
+		targets = append(targets, *t)
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Ports[0] < targets[j].Ports[0]
+	})
+	return targets
+}
+
+func printTargetTable(targets []Target) {
+	fmt.Printf("%s%-8s %-18s %-12s %-7s %-7s %-8s %-20s %s%s\n",
+		common.Bold, "PID", "COMMAND", "USER", "CPU%", "MEM%", "PROTO", "STATE", "PORTS", common.Reset)
+	fmt.Println(strings.Repeat("─", 100))
+
+	pids := make([]string, len(targets))
+	for i, t := range targets {
+		pids[i] = t.PID
+	}
+	stats := proc.BatchStats(pids)
+
+	for _, t := range targets {
+		cpu, mem := "-", "-"
+		if u, ok := stats[t.PID]; ok {
+			cpu, mem = u.CPU, u.Mem
+		}
+		fmt.Printf("%-8s %-18s %-12s %-7s %-7s %-8s %-20s %s\n",
+			t.PID, truncate(t.Command, 18), truncate(t.User, 12), cpu, mem,
+			strings.Join(t.Protocols, ","), truncate(strings.Join(t.States, ","), 20), joinPorts(t.Ports))
+		if t.Args != "" {
+			fmt.Printf("         ↳ %s\n", truncate(t.Args, 90))
+		}
+	}
+}
+
+func joinPorts(ports []int) string {
+	parts := make([]string, len(ports))
+	for i, p := range ports {
+		parts[i] = strconv.Itoa(p)
+	}
+	return strings.Join(parts, ",")
+}
+
+func containsInt(list []int, v int) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, v string) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max-1] + "…"
+}
+
+// suspendTree 종료 중 상위 watcher가 자식을 재시작하지 않도록 위에서부터 일시 정지합니다.
+// 반환된 함수는 아직 살아 있는 정지 프로세스를 재개합니다. 종료 도중 Ctrl-C를 받아도 재개한 뒤 끝냅니다.
+func suspendTree(pids []string) (release func()) {
+	var suspended []string
+	for i := len(pids) - 1; i >= 0; i-- {
+		if err := proc.Suspend(pids[i]); err != nil {
+			common.Warning("PID %s 일시 정지 실패: %v", pids[i], err)
+			continue
+		}
+		suspended = append(suspended, pids[i])
+	}
+
+	var once sync.Once
+	resume := func() {
+		once.Do(func() {
+			for _, pid := range suspended {
+				if proc.Alive(pid) {
+					proc.Resume(pid)
+				}
+			}
+		})
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-sig:
+			resume()
+			fmt.Println()
+			common.Warning("중단되었습니다. 남은 프로세스는 일시 정지를 해제했습니다")
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+
+	return func() {
+		signal.Stop(sig)
+		close(done)
+		resume()
+	}
+}
+
+// killProcess SIGTERM → (grace 경과 시) SIGKILL 순서로 프로세스를 종료합니다.
+func killProcess(pid string, grace time.Duration) {
+	forced, err := proc.Terminate(pid, grace)
+	if err != nil {
 		common.Error("PID %s 종료 실패: %v", pid, err)
 		return
 	}
+	if forced {
+		common.Success("PID %s 종료 완료 (SIGKILL)", pid)
+		return
+	}
 	common.Success("PID %s 종료 완료", pid)
 }
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/useful-go/pkg/common"
+	"github.com/useful-go/pkg/proc"
+	"github.com/useful-go/pkg/ui"
+)
+
+// 상위 프로세스 탐색을 멈추는 명령어 (셸, 터미널, 세션 관리자)
+var treeStopCommands = map[string]bool{
+	"init": true, "systemd": true, "launchd": true,
+	"bash": true, "zsh": true, "sh": true, "fish": true, "dash": true, "ksh": true, "tcsh": true, "csh": true,
+	"-bash": true, "-zsh": true, "-sh": true, "-fish": true,
+	"login": true, "sshd": true, "su": true, "sudo": true,
+	"tmux": true, "tmux: server": true, "screen": true,
+	"Terminal": true, "iTerm2": true,
+}
+
+func isTreeStop(p proc.Process) bool {
+	return treeStopCommands[p.Command]
+}
+
+// resolveTrees 각 점유 프로세스의 상위 체인과 하위 트리를 보여주고,
+// 종료할 서브트리의 루트를 선택받아 bottom-up 종료 순서를 반환합니다.
+func resolveTrees(tree *proc.Tree, targets []Target) []string {
+	var order []string
+	seen := make(map[string]bool)
+
+	for _, t := range targets {
+		ancestors := tree.Ancestors(t.PID, isTreeStop)
+
+		fmt.Println()
+		common.Header("PID %s (%s) 프로세스 트리:", t.PID, t.Command)
+
+		top := t.PID
+		if len(ancestors) > 0 {
+			top = ancestors[len(ancestors)-1].PID
+			var chain []string
+			for _, a := range ancestors {
+				chain = append(chain, fmt.Sprintf("%s %s", a.PID, a.Command))
+			}
+			fmt.Printf("  상위: %s\n", strings.Join(chain, " ← "))
+		}
+		fmt.Println()
+		printTree(tree, top, t.PID, "  ", "")
+		fmt.Println()
+
+		root := t.PID
+		if len(ancestors) > 0 {
+			candidates := map[string]bool{t.PID: true}
+			for _, a := range ancestors {
+				candidates[a.PID] = true
+			}
+			for {
+				root = ui.Input("종료할 서브트리의 루트 PID", t.PID)
+				if candidates[root] {
+					break
+				}
+				common.Warning("PID %s는 선택할 수 없습니다 (점유 프로세스 또는 표시된 상위 프로세스만 가능)", root)
+			}
+		}
+
+		for _, pid := range tree.Subtree(root) {
+			if !seen[pid] {
+				seen[pid] = true
+				order = append(order, pid)
+			}
+		}
+	}
+
+	return order
+}
+
+// printTree pid부터 하위 프로세스를 트리 형태로 출력합니다. mark는 포트 점유 프로세스입니다.
+func printTree(tree *proc.Tree, pid, mark, prefix, branch string) {
+	p, ok := tree.Get(pid)
+	if !ok {
+		return
+	}
+
+	label := fmt.Sprintf("%s %s", p.PID, p.Command)
+	if pid == mark {
+		label = common.Green + label + common.Reset + "  ← 포트 점유"
+	}
+	fmt.Printf("%s%s%s\n", prefix, branch, label)
+
+	children := tree.Children(pid)
+	childPrefix := prefix
+	switch branch {
+	case "├── ":
+		childPrefix += "│   "
+	case "└── ":
+		childPrefix += "    "
+	}
+
+	for i, c := range children {
+		b := "├── "
+		if i == len(children)-1 {
+			b = "└── "
+		}
+		printTree(tree, c, mark, childPrefix, b)
+	}
+}
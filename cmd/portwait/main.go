@@ -0,0 +1,103 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/useful-go/pkg/common"
+	"github.com/useful-go/pkg/proc"
+)
+
+func main() {
+	free := flag.Bool("free", false, "포트가 해제될 때까지 대기")
+	listening := flag.Bool("listening", false, "포트가 열릴 때까지 대기 (기본)")
+	timeout := flag.Duration("timeout", 30*time.Second, "최대 대기 시간")
+	quiet := flag.Bool("quiet", false, "결과 메시지 출력 안함")
+	help := flag.Bool("help", false, "도움말")
+	flag.BoolVar(help, "h", false, "도움말")
+
+	// 대상 뒤에 오는 옵션도 허용 (portwait localhost:5432 --timeout 10s)
+	flag.Parse()
+	var target string
+	if flag.NArg() > 0 {
+		target = flag.Arg(0)
+		flag.CommandLine.Parse(flag.Args()[1:])
+	}
+
+	if *help {
+		printUsage()
+		return
+	}
+
+	if target == "" || flag.NArg() > 0 {
+		common.Error("대기할 대상을 하나 입력해주세요")
+		fmt.Println("사용법: portwait <host:port|port> [--free|--listening] [--timeout 30s]")
+		os.Exit(1)
+	}
+
+	if *free && *listening {
+		common.Fatal("--free와 --listening은 함께 사용할 수 없습니다")
+	}
+
+	host, port, err := parseTarget(target)
+	if err != nil {
+		common.Fatal("%v", err)
+	}
+
+	wantListening := !*free
+	start := time.Now()
+
+	if err := proc.WaitPort(host, port, "tcp", wantListening, *timeout); err != nil {
+		if !*quiet {
+			common.Error("%v", err)
+		}
+		os.Exit(1)
+	}
+
+	if !*quiet {
+		elapsed := time.Since(start).Round(time.Millisecond)
+		if wantListening {
+			common.Success("%s 연결 가능 (%s)", target, elapsed)
+		} else {
+			common.Success("%s 해제됨 (%s)", target, elapsed)
+		}
+	}
+}
+
+func printUsage() {
+	common.Header("portwait - 포트가 열리거나 해제될 때까지 대기")
+	fmt.Println()
+	fmt.Println("사용법: portwait <host:port|port> [options]")
+	fmt.Println()
+	fmt.Println("옵션:")
+	fmt.Println("  --listening    포트가 열릴 때까지 대기 (기본)")
+	fmt.Println("  --free         포트가 해제될 때까지 대기")
+	fmt.Println("  --timeout D    최대 대기 시간 (기본: 30s), 초과 시 종료 코드 1")
+	fmt.Println("  --quiet        결과 메시지 출력 안함")
+	fmt.Println("  -h, --help     도움말")
+	fmt.Println()
+	fmt.Println("예시:")
+	fmt.Println("  portwait localhost:5432 --timeout 60s   # DB가 뜰 때까지 대기")
+	fmt.Println("  portwait 3000 --free                    # 서버가 내려갈 때까지 대기")
+}
+
+// parseTarget "host:port" 또는 "port"를 분리합니다. 호스트가 없으면 localhost입니다.
+func parseTarget(target string) (string, int, error) {
+	host, portStr, err := net.SplitHostPort(target)
+	if err != nil {
+		host, portStr = "localhost", target
+	}
+
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 1 || port > 65535 {
+		return "", 0, fmt.Errorf("유효하지 않은 대상: %s", target)
+	}
+	if host == "" {
+		host = "localhost"
+	}
+	return host, port, nil
+}
@@ -12,15 +12,19 @@ import (
 var commands = map[string]Command{
 	"lsport": {
 		Description: "사용 중인 포트 목록 조회",
-		Usage:       "useful lsport [--tcp] [--udp] [--listen] [--port N]",
+		Usage:       "useful lsport [--tcp] [--udp] [--listen] [--port N|N-M] [--sort KEY] [--group-by process] [--watch|-i] [--free N-M] [--unix] [--probe] | snapshot save <name> | diff <name> | serve [--addr ADDR] | check [dir]",
 	},
 	"portkill": {
 		Description: "포트를 사용하는 프로세스 종료",
-		Usage:       "useful portkill <port>",
+		Usage:       "useful portkill [--proto tcp|udp] [--tree] <port|start-end>... | --name REGEX",
+	},
+	"portwait": {
+		Description: "포트가 열리거나 해제될 때까지 대기",
+		Usage:       "useful portwait <host:port> [--free|--listening] [--timeout 30s]",
 	},
 	"logclean": {
-		Description: "macOS 로그/캐시 파일 정리",
-		Usage:       "useful logclean [--dry-run] [--days N] [--all]",
+		Description: "로그/캐시 파일 정리 (macOS, Linux)",
+		Usage:       "useful logclean [--dry-run] [--days N] [--all] [--compress] [--max-size SIZE] [--max-total SIZE] [--keep-last N] [--journal-size SIZE]",
 	},
 	"flatten": {
 		Description: "폴더 구조 평탄화 (숫자 자동 패딩)",
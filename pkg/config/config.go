@@ -0,0 +1,38 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Dir 설정 디렉토리 경로 ($XDG_CONFIG_HOME/useful 또는 ~/.config/useful)
+func Dir() string {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "useful")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "useful")
+}
+
+// Path 명령어별 설정 파일 경로 (예: ~/.config/useful/portkill.json)
+func Path(name string) string {
+	return filepath.Join(Dir(), name+".json")
+}
+
+// Load 명령어별 JSON 설정 파일을 v에 읽어옵니다. 파일이 없으면 v를 그대로 두고 nil을 반환합니다.
+func Load(name string, v interface{}) error {
+	data, err := os.ReadFile(Path(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%s 파싱 실패: %v", Path(name), err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package docker
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// 게시된 포트를 대신 점유하는 Docker 프록시 프로세스
+var proxyCommands = []string{
+	"docker-proxy",
+	"com.docker.backend",
+	"com.docker.vpnkit",
+	"vpnkit-bridge",
+	"rootlesskit",
+	"rootlessport",
+}
+
+// Container 포트를 게시한 실행 중인 컨테이너
+type Container struct {
+	ID    string
+	Name  string
+	Image string
+}
+
+// IsProxy 명령어가 Docker 포트 프록시 프로세스인지 확인합니다.
+func IsProxy(command string) bool {
+	for _, c := range proxyCommands {
+		if command == c {
+			return true
+		}
+	}
+	return false
+}
+
+// Available docker CLI가 설치되어 있는지 확인합니다.
+func Available() bool {
+	_, err := exec.LookPath("docker")
+	return err == nil
+}
+
+// PublishedPorts 실행 중인 컨테이너의 호스트 게시 포트를 조회합니다.
+// 키는 "포트/프로토콜" 형식입니다 (예: "5432/tcp").
+func PublishedPorts() (map[string]Container, error) {
+	if !Available() {
+		return nil, nil
+	}
+
+	cmd := exec.Command("docker", "ps", "--format", "{{.ID}}\t{{.Names}}\t{{.Image}}\t{{.Ports}}")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	ports := make(map[string]Container)
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 4 {
+			continue
+		}
+		c := Container{ID: fields[0], Name: fields[1], Image: fields[2]}
+		for _, key := range parsePorts(fields[3]) {
+			ports[key] = c
+		}
+	}
+	return ports, nil
+}
+
+// Key PublishedPorts 맵의 키를 만듭니다.
+func Key(port int, protocol string) string {
+	return fmt.Sprintf("%d/%s", port, strings.ToLower(protocol))
+}
+
+// parsePorts "0.0.0.0:8000-8001->80-81/tcp, :::5432->5432/tcp, 9000/tcp" 형식에서
+// 호스트에 게시된 포트 키 목록을 추출합니다.
+func parsePorts(s string) []string {
+	var keys []string
+	for _, mapping := range strings.Split(s, ",") {
+		mapping = strings.TrimSpace(mapping)
+		host, container, ok := strings.Cut(mapping, "->")
+		if !ok {
+			continue // 게시되지 않은 포트
+		}
+
+		proto := "tcp"
+		if _, p, ok := strings.Cut(container, "/"); ok {
+			proto = p
+		}
+
+		hostPorts := host[strings.LastIndex(host, ":")+1:]
+		start, end := hostPorts, hostPorts
+		if lo, hi, ok := strings.Cut(hostPorts, "-"); ok {
+			start, end = lo, hi
+		}
+
+		from, err1 := strconv.Atoi(start)
+		to, err2 := strconv.Atoi(end)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		for p := from; p <= to; p++ {
+			keys = append(keys, Key(p, proto))
+		}
+	}
+	return keys
+}
+
+// Stop 컨테이너를 중지합니다.
+func Stop(name string) error {
+	output, err := exec.Command("docker", "stop", name).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
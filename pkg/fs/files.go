@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -27,6 +28,36 @@ func FormatSize(bytes int64) string {
 	}
 }
 
+// ParseSize "2GB", "500M", "1.5G", "100KB", "4096" 같은 크기 문자열을 바이트로 변환합니다 (1024 단위).
+func ParseSize(s string) (int64, error) {
+	str := strings.ToUpper(strings.TrimSpace(s))
+	str = strings.TrimSuffix(str, "B")
+	str = strings.TrimSuffix(str, "I") // GiB, MiB
+
+	mult := int64(1)
+	if n := len(str); n > 0 {
+		switch str[n-1] {
+		case 'K':
+			mult = 1 << 10
+		case 'M':
+			mult = 1 << 20
+		case 'G':
+			mult = 1 << 30
+		case 'T':
+			mult = 1 << 40
+		}
+		if mult > 1 {
+			str = str[:n-1]
+		}
+	}
+
+	v, err := strconv.ParseFloat(strings.TrimSpace(str), 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("유효하지 않은 크기: %s", s)
+	}
+	return int64(v * float64(mult)), nil
+}
+
 // ExpandPath ~를 home 디렉토리로 변환
 func ExpandPath(path string) string {
 	if strings.HasPrefix(path, "~/") {
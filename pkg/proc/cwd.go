@@ -0,0 +1,11 @@
+package proc
+
+// WorkingDirs 여러 PID의 현재 작업 디렉토리를 조회합니다.
+// 권한이 없어 읽지 못한 PID는 결과에 없습니다.
+func WorkingDirs(pids []string) map[string]string {
+	result := make(map[string]string)
+	if len(pids) == 0 {
+		return result
+	}
+	return readWorkingDirs(uniq(pids), result)
+}
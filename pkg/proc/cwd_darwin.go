@@ -0,0 +1,30 @@
+package proc
+
+import (
+	"os/exec"
+	"strings"
+)
+
+func readWorkingDirs(pids []string, result map[string]string) map[string]string {
+	// -F pn: "p<pid>" 다음 줄에 "n<경로>"
+	output, err := exec.Command("lsof", "-a", "-d", "cwd", "-p", strings.Join(pids, ","), "-F", "pn").Output()
+	if err != nil && len(output) == 0 {
+		return result
+	}
+
+	var pid string
+	for _, line := range strings.Split(string(output), "\n") {
+		if len(line) < 2 {
+			continue
+		}
+		switch line[0] {
+		case 'p':
+			pid = line[1:]
+		case 'n':
+			if pid != "" {
+				result[pid] = line[1:]
+			}
+		}
+	}
+	return result
+}
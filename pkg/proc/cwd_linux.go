@@ -0,0 +1,12 @@
+package proc
+
+import "os"
+
+func readWorkingDirs(pids []string, result map[string]string) map[string]string {
+	for _, pid := range pids {
+		if dir, err := os.Readlink("/proc/" + pid + "/cwd"); err == nil {
+			result[pid] = dir
+		}
+	}
+	return result
+}
@@ -0,0 +1,7 @@
+//go:build !linux && !darwin
+
+package proc
+
+func readWorkingDirs(pids []string, result map[string]string) map[string]string {
+	return result
+}
@@ -0,0 +1,68 @@
+package proc
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// FreePorts from~to 범위에서 사용 가능한 포트를 낮은 번호부터 count개 찾습니다.
+// 소켓 테이블에서 사용 중인 포트(TCP는 TIME_WAIT 등 모든 상태)를 건너뛰고,
+// 남은 포트는 host에 실제로 bind해 확인합니다. protos의 모든 프로토콜에서 비어 있어야 합니다.
+func FreePorts(host string, from, to, count int, protos []string) ([]int, error) {
+	used := make(map[string]bool)
+	for _, proto := range protos {
+		sockets, err := ListSockets(proto)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range sockets {
+			used[proto+"/"+strconv.Itoa(s.Port)] = true
+		}
+		if proto == "tcp" {
+			// lsof로 보이지 않는 다른 사용자의 소켓, 소유자 없는 TIME_WAIT 포함
+			conns, _ := TCPTable()
+			for _, c := range conns {
+				used["tcp/"+strconv.Itoa(c.LocalPort)] = true
+			}
+		}
+	}
+
+	var ports []int
+	for port := from; port <= to && len(ports) < count; port++ {
+		free := true
+		for _, proto := range protos {
+			if used[proto+"/"+strconv.Itoa(port)] || !canBind(host, port, proto) {
+				free = false
+				break
+			}
+		}
+		if free {
+			ports = append(ports, port)
+		}
+	}
+
+	if len(ports) < count {
+		return ports, fmt.Errorf("%d-%d 범위에 사용 가능한 포트가 %d개뿐입니다 (요청: %d개)", from, to, len(ports), count)
+	}
+	return ports, nil
+}
+
+func canBind(host string, port int, proto string) bool {
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	if proto == "udp" {
+		conn, err := net.ListenPacket("udp", addr)
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return false
+	}
+	ln.Close()
+	return true
+}
@@ -0,0 +1,86 @@
+package proc
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// DefaultGrace SIGTERM 후 SIGKILL까지 기다리는 기본 시간
+const DefaultGrace = 3 * time.Second
+
+// Terminate SIGTERM을 보내고 grace 동안 종료를 기다린 뒤, 살아 있으면 SIGKILL을 보냅니다.
+// grace가 0이면 바로 SIGKILL을 보냅니다. forced는 SIGKILL을 보냈는지 여부입니다.
+func Terminate(pid string, grace time.Duration) (forced bool, err error) {
+	p, err := findProcess(pid)
+	if err != nil {
+		return false, err
+	}
+
+	if grace > 0 {
+		err := p.Signal(syscall.SIGTERM)
+		// Suspend로 정지된 프로세스도 SIGTERM을 처리할 수 있도록 재개 (실패해도 정지 상태로 남기지 않음)
+		Resume(pid)
+		if err != nil {
+			return false, err
+		}
+		deadline := time.Now().Add(grace)
+		for time.Now().Before(deadline) {
+			if !Alive(pid) {
+				return false, nil
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		if !Alive(pid) {
+			return false, nil
+		}
+	}
+
+	if err := p.Signal(syscall.SIGKILL); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// Kill 보호 정책을 확인한 뒤 Terminate로 종료합니다.
+// 목록을 보여주지 않고 단일 PID를 종료하는 곳(lsport 등)에서 portkill과 같은 규칙을 적용할 때 사용합니다.
+func Kill(pid string, policy Policy, grace time.Duration) (forced bool, err error) {
+	tree, err := LoadTree()
+	if err != nil {
+		return false, err
+	}
+	if reason, ok := policy.Check(tree, pid); !ok {
+		return false, fmt.Errorf("보호 정책으로 건너뜀: %s", reason)
+	}
+	return Terminate(pid, grace)
+}
+
+// Alive 프로세스가 아직 실행 중인지 확인합니다.
+// 부모가 정지되어 회수되지 않은 좀비 프로세스는 종료된 것으로 봅니다.
+func Alive(pid string) bool {
+	p, err := findProcess(pid)
+	if err != nil {
+		return false
+	}
+	if p.Signal(syscall.Signal(0)) != nil {
+		return false
+	}
+
+	out, err := exec.Command("ps", "-o", "stat=", "-p", pid).Output()
+	if err != nil {
+		return false
+	}
+	return !strings.HasPrefix(strings.TrimSpace(string(out)), "Z")
+}
+
+func findProcess(pid string) (*os.Process, error) {
+	n, err := strconv.Atoi(pid)
+	if err != nil || n <= 0 {
+		return nil, fmt.Errorf("유효하지 않은 PID: %s", pid)
+	}
+	return os.FindProcess(n)
+}
@@ -0,0 +1,103 @@
+package proc
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Usage 프로세스의 CPU%, MEM%
+type Usage struct {
+	CPU string
+	Mem string
+}
+
+// BatchStats 여러 PID의 CPU%, MEM%를 한 번의 ps 호출로 조회합니다.
+// 조회되지 않은 PID는 결과에 없으므로 호출 측에서 "-"로 표시합니다.
+func BatchStats(pids []string) map[string]Usage {
+	result := make(map[string]Usage)
+	if len(pids) == 0 {
+		return result
+	}
+
+	cmd := exec.Command("ps", "-p", strings.Join(uniq(pids), ","), "-o", "pid=,%cpu=,%mem=")
+	output, err := cmd.Output()
+	if err != nil && len(output) == 0 {
+		return result
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		result[fields[0]] = Usage{CPU: fields[1], Mem: fields[2]}
+	}
+	return result
+}
+
+// CommandLines 여러 PID의 전체 명령줄(argv)을 한 번의 ps 호출로 조회합니다.
+func CommandLines(pids []string) map[string]string {
+	result := make(map[string]string)
+	if len(pids) == 0 {
+		return result
+	}
+
+	cmd := exec.Command("ps", "-ww", "-p", strings.Join(uniq(pids), ","), "-o", "pid=,args=")
+	output, err := cmd.Output()
+	if err != nil && len(output) == 0 {
+		return result
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		pid, args, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		result[pid] = strings.TrimSpace(args)
+	}
+	return result
+}
+
+// StartTimes 여러 PID의 시작 시각을 한 번의 ps 호출로 조회합니다.
+func StartTimes(pids []string) map[string]time.Time {
+	result := make(map[string]time.Time)
+	if len(pids) == 0 {
+		return result
+	}
+
+	// lstart 형식을 고정하기 위해 C 로캘 사용 ("Mon Oct  8 12:34:56 2026")
+	cmd := exec.Command("ps", "-p", strings.Join(uniq(pids), ","), "-o", "pid=,lstart=")
+	cmd.Env = append(os.Environ(), "LC_ALL=C")
+	output, err := cmd.Output()
+	if err != nil && len(output) == 0 {
+		return result
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 6 {
+			continue
+		}
+		t, err := time.ParseInLocation("Mon Jan 2 15:04:05 2006", strings.Join(fields[1:6], " "), time.Local)
+		if err != nil {
+			continue
+		}
+		result[fields[0]] = t
+	}
+	return result
+}
+
+func uniq(list []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, v := range list {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
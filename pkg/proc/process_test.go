@@ -0,0 +1,67 @@
+package proc
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// 벤치마크에서 조회할 프로세스 수 (포트를 가진 프로세스가 많은 개발 환경 가정)
+const benchProcesses = 30
+
+// startSleepers 조회 대상으로 쓸 sleep 프로세스를 띄우고 PID 목록을 반환합니다.
+func startSleepers(b *testing.B, n int) []string {
+	b.Helper()
+	var pids []string
+	for i := 0; i < n; i++ {
+		cmd := exec.Command("sleep", "60")
+		if err := cmd.Start(); err != nil {
+			b.Skipf("sleep 실행 실패: %v", err)
+		}
+		b.Cleanup(func() {
+			cmd.Process.Kill()
+			cmd.Wait()
+		})
+		pids = append(pids, strconv.Itoa(cmd.Process.Pid))
+	}
+	return pids
+}
+
+// perPIDStats 예전 lsport 방식: PID마다 ps를 한 번씩 실행
+func perPIDStats(pids []string) map[string]Usage {
+	result := make(map[string]Usage)
+	for _, pid := range pids {
+		output, err := exec.Command("ps", "-p", pid, "-o", "%cpu=,%mem=").Output()
+		if err != nil {
+			continue
+		}
+		fields := strings.Fields(string(output))
+		if len(fields) >= 2 {
+			result[pid] = Usage{CPU: fields[0], Mem: fields[1]}
+		}
+	}
+	return result
+}
+
+func BenchmarkStats(b *testing.B) {
+	if _, err := exec.LookPath("ps"); err != nil {
+		b.Skip("ps를 찾을 수 없습니다")
+	}
+	pids := startSleepers(b, benchProcesses)
+
+	b.Run("PerPID", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if got := perPIDStats(pids); len(got) != len(pids) {
+				b.Fatalf("%d개 중 %d개만 조회됨", len(pids), len(got))
+			}
+		}
+	})
+	b.Run("Batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if got := BatchStats(pids); len(got) != len(pids) {
+				b.Fatalf("%d개 중 %d개만 조회됨", len(pids), len(got))
+			}
+		}
+	})
+}
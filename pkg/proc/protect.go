@@ -0,0 +1,86 @@
+package proc
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/useful-go/pkg/config"
+)
+
+// DefaultDenyCommands 기본으로 종료를 막는 시스템/인프라 프로세스
+var DefaultDenyCommands = []string{
+	"init", "systemd", "launchd", "kernel_task",
+	"sshd", "loginwindow", "WindowServer",
+	"dockerd", "containerd", "docker-proxy", "com.docker.backend", "com.docker.vmnetd",
+}
+
+// Policy 프로세스 종료 보호 정책
+// ~/.config/useful/portkill.json 의 allow/deny 목록(명령어 이름, glob 패턴 가능)으로 확장합니다.
+type Policy struct {
+	Allow []string `json:"allow"` // 명령어 거부 목록에서 제외할 명령어 (다른 사용자 규칙에는 적용되지 않음)
+	Deny  []string `json:"deny"`  // 추가로 종료를 막을 명령어
+	Force bool     `json:"-"`     // 다른 사용자 프로세스 및 명령어 거부 목록 무시
+}
+
+// LoadPolicy 기본 정책에 설정 파일의 allow/deny 목록을 합칩니다.
+func LoadPolicy() (Policy, error) {
+	var p Policy
+	err := config.Load("portkill", &p)
+	return p, err
+}
+
+// Check 프로세스를 종료해도 되는지 확인하고, 막힌 경우 이유를 반환합니다.
+// PID 1, 커널 스레드, 자기 자신과 상위 셸은 --force로도 종료할 수 없습니다.
+func (p Policy) Check(tree *Tree, pid string) (reason string, ok bool) {
+	if pid == "0" || pid == "1" {
+		return "PID " + pid + "은(는) 시스템 init 프로세스입니다", false
+	}
+
+	self := strconv.Itoa(os.Getpid())
+	if pid == self {
+		return "현재 실행 중인 명령 자신입니다", false
+	}
+	for _, a := range tree.Ancestors(self, nil) {
+		if a.PID == pid {
+			return "이 명령을 실행한 상위 프로세스(셸)입니다", false
+		}
+	}
+
+	pr, exists := tree.Get(pid)
+	if !exists {
+		return "", true // 이미 종료된 프로세스
+	}
+
+	if pr.PID == "2" || pr.PPID == "2" {
+		return "커널 스레드입니다", false
+	}
+
+	// allow는 명령어 거부 목록에만 적용 (다른 사용자 규칙은 --force로만 무시)
+	if !matchCommand(p.Allow, pr.Command) && !p.Force {
+		if matchCommand(p.Deny, pr.Command) {
+			return pr.Command + "은(는) 설정의 deny 목록에 있습니다 (--force로 무시)", false
+		}
+		if matchCommand(DefaultDenyCommands, pr.Command) {
+			return pr.Command + "은(는) 보호된 시스템 프로세스입니다 (--force로 무시, 또는 설정의 allow에 추가)", false
+		}
+	}
+
+	if pr.UID != strconv.Itoa(os.Getuid()) && !p.Force {
+		return "다른 사용자(" + pr.User + ")의 프로세스입니다 (--force로 무시)", false
+	}
+
+	return "", true
+}
+
+func matchCommand(patterns []string, command string) bool {
+	for _, pattern := range patterns {
+		if pattern == command {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, command); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package proc
+
+import (
+	"net"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Socket lsof -i 결과 한 줄에 해당하는 네트워크 소켓 정보
+type Socket struct {
+	Command    string
+	PID        string
+	User       string
+	Protocol   string // TCP, UDP
+	State      string // LISTEN, ESTABLISHED 등 (UDP는 빈 값)
+	Family     string // IPv4, IPv6
+	LocalAddr  string // 로컬 주소 (와일드카드는 "*")
+	Port       int    // 로컬 포트
+	RemoteAddr string // 원격 주소 (연결된 소켓만)
+	RemotePort int    // 원격 포트 (연결된 소켓만, 없으면 0)
+}
+
+// 포트 추출 정규식 (로컬:포트[->원격:포트])
+var portRegex = regexp.MustCompile(`:(\d+)(?:\s|$|->)`)
+
+// ListSockets lsof로 네트워크 소켓 목록을 조회합니다.
+// filters는 lsof -i 인자로 전달됩니다 (예: "tcp", "udp:53"). 비어 있으면 전체 조회.
+func ListSockets(filters ...string) ([]Socket, error) {
+	// -P: 포트 숫자로 표시, -n: DNS 해석 안함, +c 0: 명령어 이름 자르지 않음
+	args := []string{"-P", "-n", "+c", "0"}
+	if len(filters) == 0 {
+		args = append(args, "-i")
+	}
+	for _, f := range filters {
+		args = append(args, "-i", f)
+	}
+
+	output, err := exec.Command("lsof", args...).Output()
+	if err != nil {
+		// 일치하는 소켓이 없으면 lsof는 exit 1로 종료
+		if _, ok := err.(*exec.ExitError); !ok {
+			return nil, err
+		}
+	}
+
+	return parseLsof(string(output)), nil
+}
+
+func parseLsof(output string) []Socket {
+	var sockets []Socket
+
+	for i, line := range strings.Split(output, "\n") {
+		if i == 0 || strings.TrimSpace(line) == "" {
+			continue // 헤더 스킵
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 9 {
+			continue
+		}
+
+		name := fields[8] // 연결 정보
+		matches := portRegex.FindStringSubmatch(name)
+		if len(matches) < 2 {
+			continue
+		}
+		port, err := strconv.Atoi(matches[1])
+		if err != nil {
+			continue
+		}
+
+		s := Socket{
+			Command:  fields[0],
+			PID:      fields[1],
+			User:     fields[2],
+			Family:   fields[4],                  // IPv4, IPv6
+			Protocol: strings.ToUpper(fields[7]), // TCP, UDP
+			Port:     port,
+		}
+
+		local, remote, connected := strings.Cut(name, "->")
+		s.LocalAddr, _ = splitAddr(local)
+		if connected {
+			s.RemoteAddr, s.RemotePort = splitAddr(remote)
+		}
+
+		// 괄호 제거: (LISTEN) -> LISTEN
+		if len(fields) >= 10 {
+			s.State = strings.Trim(fields[9], "()")
+		}
+
+		sockets = append(sockets, s)
+	}
+
+	return sockets
+}
+
+// splitAddr lsof NAME의 "주소:포트" 부분을 분리합니다. IPv6 주소의 대괄호는 제거합니다.
+func splitAddr(s string) (string, int) {
+	c := strings.LastIndex(s, ":")
+	if c < 0 {
+		return s, 0
+	}
+	port, _ := strconv.Atoi(s[c+1:])
+	return strings.Trim(s[:c], "[]"), port
+}
+
+// IsWildcard 모든 인터페이스에 bind된 주소인지 확인합니다.
+func IsWildcard(addr string) bool {
+	return addr == "*" || addr == "0.0.0.0" || addr == "::" || addr == ""
+}
+
+// IsLoopback 루프백 주소(127.0.0.0/8, ::1)인지 확인합니다.
+func IsLoopback(addr string) bool {
+	if addr == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(addr)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package proc
+
+// Suspend 일시 정지 신호가 없는 OS에서는 아무것도 하지 않습니다.
+func Suspend(pid string) error {
+	return nil
+}
+
+// Resume 일시 정지 신호가 없는 OS에서는 아무것도 하지 않습니다.
+func Resume(pid string) error {
+	return nil
+}
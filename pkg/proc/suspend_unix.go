@@ -0,0 +1,24 @@
+//go:build unix
+
+package proc
+
+import "syscall"
+
+// Suspend 프로세스를 일시 정지(SIGSTOP)합니다.
+// 서브트리를 종료하는 동안 부모 watcher가 자식을 다시 띄우지 못하게 할 때 사용합니다.
+func Suspend(pid string) error {
+	p, err := findProcess(pid)
+	if err != nil {
+		return err
+	}
+	return p.Signal(syscall.SIGSTOP)
+}
+
+// Resume Suspend로 정지한 프로세스를 재개(SIGCONT)합니다.
+func Resume(pid string) error {
+	p, err := findProcess(pid)
+	if err != nil {
+		return err
+	}
+	return p.Signal(syscall.SIGCONT)
+}
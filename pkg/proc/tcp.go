@@ -0,0 +1,15 @@
+package proc
+
+// TCPConn 커널 TCP 테이블의 한 항목. lsof와 달리 소유 프로세스가 없는 TIME_WAIT 소켓도 포함합니다.
+type TCPConn struct {
+	LocalAddr  string
+	LocalPort  int
+	RemoteAddr string
+	RemotePort int
+	State      string // ESTABLISHED, CLOSE_WAIT, TIME_WAIT, LISTEN 등
+}
+
+// TCPTable 현재 TCP 연결 테이블을 조회합니다 (Linux: /proc/net/tcp*, macOS: netstat).
+func TCPTable() ([]TCPConn, error) {
+	return readTCPTable()
+}
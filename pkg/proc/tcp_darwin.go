@@ -0,0 +1,47 @@
+package proc
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+func readTCPTable() ([]TCPConn, error) {
+	output, err := exec.Command("netstat", "-an", "-p", "tcp").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var conns []TCPConn
+	for _, line := range strings.Split(string(output), "\n") {
+		// tcp4  0  0  127.0.0.1.5432  127.0.0.1.54321  ESTABLISHED
+		fields := strings.Fields(line)
+		if len(fields) < 6 || !strings.HasPrefix(fields[0], "tcp") {
+			continue
+		}
+		localAddr, localPort := parseNetstatAddr(fields[3])
+		remoteAddr, remotePort := parseNetstatAddr(fields[4])
+		conns = append(conns, TCPConn{
+			LocalAddr:  localAddr,
+			LocalPort:  localPort,
+			RemoteAddr: remoteAddr,
+			RemotePort: remotePort,
+			State:      fields[5],
+		})
+	}
+	return conns, nil
+}
+
+// parseNetstatAddr "127.0.0.1.5432", "*.5432", "::1.5432" 형식을 변환합니다.
+func parseNetstatAddr(s string) (string, int) {
+	i := strings.LastIndex(s, ".")
+	if i < 0 {
+		return s, 0
+	}
+	port, _ := strconv.Atoi(s[i+1:])
+	addr := s[:i]
+	if j := strings.Index(addr, "%"); j >= 0 {
+		addr = addr[:j] // 링크 로컬 인터페이스 표기 제거
+	}
+	return addr, port
+}
@@ -0,0 +1,77 @@
+package proc
+
+import (
+	"encoding/hex"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// /proc/net/tcp 상태 코드
+var tcpStates = map[string]string{
+	"01": "ESTABLISHED",
+	"02": "SYN_SENT",
+	"03": "SYN_RECV",
+	"04": "FIN_WAIT1",
+	"05": "FIN_WAIT2",
+	"06": "TIME_WAIT",
+	"07": "CLOSE",
+	"08": "CLOSE_WAIT",
+	"09": "LAST_ACK",
+	"0A": "LISTEN",
+	"0B": "CLOSING",
+}
+
+func readTCPTable() ([]TCPConn, error) {
+	var conns []TCPConn
+	for _, path := range []string{"/proc/net/tcp", "/proc/net/tcp6"} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		for i, line := range strings.Split(string(data), "\n") {
+			fields := strings.Fields(line)
+			if i == 0 || len(fields) < 4 {
+				continue // 헤더 스킵
+			}
+			localAddr, localPort := parseProcAddr(fields[1])
+			remoteAddr, remotePort := parseProcAddr(fields[2])
+			conns = append(conns, TCPConn{
+				LocalAddr:  localAddr,
+				LocalPort:  localPort,
+				RemoteAddr: remoteAddr,
+				RemotePort: remotePort,
+				State:      tcpStates[fields[3]],
+			})
+		}
+	}
+	return conns, nil
+}
+
+// parseProcAddr "0100007F:1F90" 형식(32비트 단위 리틀 엔디언 주소:16진수 포트)을 변환합니다.
+func parseProcAddr(s string) (string, int) {
+	hexAddr, hexPort, ok := strings.Cut(s, ":")
+	if !ok {
+		return "", 0
+	}
+	port, _ := strconv.ParseInt(hexPort, 16, 32)
+
+	raw, err := hex.DecodeString(hexAddr)
+	if err != nil || len(raw)%4 != 0 {
+		return "", int(port)
+	}
+	for i := 0; i < len(raw); i += 4 {
+		raw[i], raw[i+1], raw[i+2], raw[i+3] = raw[i+3], raw[i+2], raw[i+1], raw[i]
+	}
+
+	ip := net.IP(raw)
+	if ip.IsUnspecified() {
+		return "*", int(port)
+	}
+	return ip.String(), int(port)
+}
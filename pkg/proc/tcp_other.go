@@ -0,0 +1,7 @@
+//go:build !linux && !darwin
+
+package proc
+
+func readTCPTable() ([]TCPConn, error) {
+	return nil, nil
+}
@@ -0,0 +1,120 @@
+package proc
+
+import (
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Process ps로 조회한 프로세스 정보
+type Process struct {
+	PID     string
+	PPID    string
+	UID     string
+	User    string
+	Command string
+}
+
+// Tree 부모/자식 관계로 연결된 프로세스 목록
+type Tree struct {
+	procs    map[string]Process
+	children map[string][]string
+}
+
+// LoadTree 현재 실행 중인 모든 프로세스의 트리를 구성합니다.
+func LoadTree() (*Tree, error) {
+	output, err := exec.Command("ps", "-A", "-o", "pid=,ppid=,uid=,user=,comm=").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Tree{
+		procs:    make(map[string]Process),
+		children: make(map[string][]string),
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+		p := Process{
+			PID:     fields[0],
+			PPID:    fields[1],
+			UID:     fields[2],
+			User:    fields[3],
+			Command: filepath.Base(strings.Join(fields[4:], " ")),
+		}
+		t.procs[p.PID] = p
+		t.children[p.PPID] = append(t.children[p.PPID], p.PID)
+	}
+
+	for ppid := range t.children {
+		sort.Slice(t.children[ppid], func(i, j int) bool {
+			return pidLess(t.children[ppid][i], t.children[ppid][j])
+		})
+	}
+
+	return t, nil
+}
+
+// Get PID에 해당하는 프로세스를 반환합니다.
+func (t *Tree) Get(pid string) (Process, bool) {
+	p, ok := t.procs[pid]
+	return p, ok
+}
+
+// Children 직계 자식 PID 목록을 반환합니다.
+func (t *Tree) Children(pid string) []string {
+	return t.children[pid]
+}
+
+// Ancestors 부모부터 시작해 루트 방향으로 상위 프로세스 목록을 반환합니다.
+// stop이 true를 반환하는 프로세스(셸, init 등)에서 멈추며, 해당 프로세스는 포함하지 않습니다.
+func (t *Tree) Ancestors(pid string, stop func(Process) bool) []Process {
+	var chain []Process
+	seen := map[string]bool{pid: true}
+
+	p, ok := t.procs[pid]
+	for ok {
+		parent, exists := t.procs[p.PPID]
+		if !exists || seen[parent.PID] || parent.PID == "0" || parent.PID == "1" {
+			break
+		}
+		if stop != nil && stop(parent) {
+			break
+		}
+		seen[parent.PID] = true
+		chain = append(chain, parent)
+		p = parent
+	}
+	return chain
+}
+
+// Subtree pid와 모든 하위 프로세스를 자식이 부모보다 먼저 오는 순서(bottom-up)로 반환합니다.
+func (t *Tree) Subtree(pid string) []string {
+	var order []string
+	seen := make(map[string]bool)
+
+	var visit func(string)
+	visit = func(p string) {
+		if seen[p] {
+			return
+		}
+		seen[p] = true
+		for _, c := range t.children[p] {
+			visit(c)
+		}
+		order = append(order, p)
+	}
+	visit(pid)
+	return order
+}
+
+func pidLess(a, b string) bool {
+	na, _ := strconv.Atoi(a)
+	nb, _ := strconv.Atoi(b)
+	return na < nb
+}
@@ -0,0 +1,37 @@
+package proc
+
+// UnixSocket 경로(또는 Linux 추상 이름 "@...")가 있는 Unix 도메인 소켓
+type UnixSocket struct {
+	Path      string
+	Type      string // stream, dgram, seqpacket (알 수 없으면 빈 값)
+	State     string // LISTEN, CONNECTED, UNCONNECTED
+	Listening bool   // 연결을 받는 stream 소켓 또는 bind된 dgram 소켓
+	PID       string
+	Command   string
+	User      string
+}
+
+// UnixSockets Unix 도메인 소켓 목록을 조회합니다 (Linux: /proc/net/unix, macOS: lsof -U).
+// 권한이 없어 소유 프로세스를 알 수 없는 소켓은 PID가 빈 값입니다.
+func UnixSockets() ([]UnixSocket, error) {
+	sockets, err := readUnixSockets()
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := LoadTree()
+	if err != nil {
+		return sockets, nil
+	}
+	for i := range sockets {
+		if p, ok := tree.Get(sockets[i].PID); ok {
+			if sockets[i].Command == "" {
+				sockets[i].Command = p.Command
+			}
+			if sockets[i].User == "" {
+				sockets[i].User = p.User
+			}
+		}
+	}
+	return sockets, nil
+}
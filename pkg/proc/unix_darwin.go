@@ -0,0 +1,66 @@
+package proc
+
+import (
+	"os/exec"
+	"strings"
+)
+
+func readUnixSockets() ([]UnixSocket, error) {
+	// -F pcLn: "p<pid>", "c<command>", "L<user>" 다음에 파일마다 "f<fd>", "n<이름>"
+	output, err := exec.Command("lsof", "-U", "-n", "-P", "+c", "0", "-F", "pcLn").Output()
+	if err != nil && len(output) == 0 {
+		return nil, err
+	}
+
+	types := unixSocketTypes()
+
+	var sockets []UnixSocket
+	var pid, command, user string
+	for _, line := range strings.Split(string(output), "\n") {
+		if len(line) < 2 {
+			continue
+		}
+		value := line[1:]
+		switch line[0] {
+		case 'p':
+			pid = value
+		case 'c':
+			command = value
+		case 'L':
+			user = value
+		case 'n':
+			// 연결된 소켓은 "->0x..." 형태로 경로가 없음
+			if !strings.HasPrefix(value, "/") {
+				continue
+			}
+			s := UnixSocket{Path: value, Type: types[value], PID: pid, Command: command, User: user}
+			// lsof는 Unix 소켓 상태를 보여주지 않으므로 경로에 bind된 소켓을 리스너로 봅니다.
+			s.Listening = true
+			s.State = "LISTEN"
+			if s.Type == "dgram" {
+				s.State = "UNCONNECTED"
+			}
+			sockets = append(sockets, s)
+		}
+	}
+	return sockets, nil
+}
+
+// unixSocketTypes netstat으로 경로별 소켓 종류(stream/dgram)를 조회합니다.
+func unixSocketTypes() map[string]string {
+	types := make(map[string]string)
+	output, err := exec.Command("netstat", "-f", "unix", "-an").Output()
+	if err != nil {
+		return types
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		// Address Type Recv-Q Send-Q Inode Conn Refs Nextref Addr
+		fields := strings.Fields(line)
+		if len(fields) < 9 || !strings.HasPrefix(fields[len(fields)-1], "/") {
+			continue
+		}
+		types[fields[len(fields)-1]] = fields[1]
+	}
+	return types
+}
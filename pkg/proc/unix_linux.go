@@ -0,0 +1,79 @@
+package proc
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// /proc/net/unix Flags의 __SO_ACCEPTCON (listen 중인 소켓)
+const unixAcceptCon = 0x10000
+
+var unixTypes = map[string]string{
+	"0001": "stream",
+	"0002": "dgram",
+	"0005": "seqpacket",
+}
+
+var unixStates = map[string]string{
+	"01": "UNCONNECTED",
+	"03": "CONNECTED",
+}
+
+func readUnixSockets() ([]UnixSocket, error) {
+	data, err := os.ReadFile("/proc/net/unix")
+	if err != nil {
+		return nil, err
+	}
+
+	owners := socketOwners()
+
+	var sockets []UnixSocket
+	for i, line := range strings.Split(string(data), "\n") {
+		// Num RefCount Protocol Flags Type St Inode Path
+		fields := strings.Fields(line)
+		if i == 0 || len(fields) < 8 {
+			continue // 헤더, 경로 없는 소켓 스킵
+		}
+
+		flags, _ := strconv.ParseUint(fields[3], 16, 32)
+		s := UnixSocket{
+			Path:  strings.Join(fields[7:], " "),
+			Type:  unixTypes[fields[4]],
+			State: unixStates[fields[5]],
+			PID:   owners[fields[6]],
+		}
+		if flags&unixAcceptCon != 0 {
+			s.State = "LISTEN"
+		}
+		s.Listening = s.State == "LISTEN" || (s.Type == "dgram" && s.State == "UNCONNECTED")
+		sockets = append(sockets, s)
+	}
+	return sockets, nil
+}
+
+// socketOwners /proc/<pid>/fd를 훑어 소켓 inode → PID를 만듭니다.
+// 여러 프로세스가 같은 소켓을 공유하면(fork) 먼저 찾은 PID를 사용합니다.
+func socketOwners() map[string]string {
+	owners := make(map[string]string)
+	dirs, _ := filepath.Glob("/proc/[0-9]*/fd")
+	for _, dir := range dirs {
+		pid := filepath.Base(filepath.Dir(dir))
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			link, err := os.Readlink(filepath.Join(dir, e.Name()))
+			if err != nil || !strings.HasPrefix(link, "socket:[") {
+				continue
+			}
+			inode := strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]")
+			if _, ok := owners[inode]; !ok {
+				owners[inode] = pid
+			}
+		}
+	}
+	return owners
+}
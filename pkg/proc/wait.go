@@ -0,0 +1,76 @@
+package proc
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+)
+
+// DefaultWaitInterval 포트 상태 확인 간격
+const DefaultWaitInterval = 500 * time.Millisecond
+
+// PortInUse 포트가 사용 중인지 확인합니다.
+// 로컬 호스트는 소켓 테이블(LISTEN 또는 bind된 UDP)을 확인하고, TCP는 실제 연결도 시도합니다.
+// proto는 "tcp", "udp" 또는 빈 값(둘 다)입니다.
+func PortInUse(host string, port int, proto string) bool {
+	if IsLocalHost(host) {
+		filter := ":" + strconv.Itoa(port)
+		if proto != "" {
+			filter = proto + filter
+		}
+		sockets, _ := ListSockets(filter)
+		for _, s := range sockets {
+			if s.Port != port {
+				continue
+			}
+			if s.State == "LISTEN" || (s.Protocol == "UDP" && s.RemotePort == 0) {
+				return true
+			}
+		}
+	}
+
+	if proto == "udp" {
+		return false
+	}
+	return canConnect(host, port)
+}
+
+// WaitPort 포트가 원하는 상태가 될 때까지 기다립니다.
+// listening이 true면 사용 중이 될 때까지, false면 해제될 때까지 기다리며 timeout이 지나면 에러를 반환합니다.
+func WaitPort(host string, port int, proto string, listening bool, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		if PortInUse(host, port, proto) == listening {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			if listening {
+				return fmt.Errorf("%s 포트가 %s 안에 열리지 않았습니다", net.JoinHostPort(host, strconv.Itoa(port)), timeout)
+			}
+			return fmt.Errorf("%s 포트가 %s 안에 해제되지 않았습니다", net.JoinHostPort(host, strconv.Itoa(port)), timeout)
+		}
+		time.Sleep(DefaultWaitInterval)
+	}
+}
+
+// IsLocalHost 소켓 테이블로 확인할 수 있는 로컬 주소인지 확인합니다.
+func IsLocalHost(host string) bool {
+	switch host {
+	case "", "localhost", "127.0.0.1", "::1", "0.0.0.0", "::":
+		return true
+	}
+	return false
+}
+
+func canConnect(host string, port int) bool {
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, strconv.Itoa(port)), 500*time.Millisecond)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
@@ -1,9 +1,7 @@
 package ui
 
 import (
-	"bufio"
 	"fmt"
-	"os"
 	"strings"
 )
 
@@ -37,15 +35,13 @@ func YesNoConfirmation(message string) *Confirmation {
 
 // Prompt 확인 메시지를 표시하고 사용자 응답을 반환합니다.
 func (c *Confirmation) Prompt() bool {
-	reader := bufio.NewReader(os.Stdin)
-
 	if c.Default {
 		fmt.Printf("%s (Y/n): ", c.Message)
 	} else {
 		fmt.Printf("%s (y/N): ", c.Message)
 	}
 
-	answer, _ := reader.ReadString('\n')
+	answer, _ := stdin.ReadString('\n')
 	answer = strings.TrimSpace(strings.ToLower(answer))
 
 	if answer == "" {
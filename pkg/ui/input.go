@@ -0,0 +1,28 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// stdin 여러 프롬프트가 버퍼를 공유하도록 하나의 reader를 사용합니다.
+var stdin = bufio.NewReader(os.Stdin)
+
+// Input 메시지를 표시하고 한 줄 입력을 받습니다. 빈 입력이면 기본값을 반환합니다.
+func Input(message, defaultValue string) string {
+	if defaultValue != "" {
+		fmt.Printf("%s [%s]: ", message, defaultValue)
+	} else {
+		fmt.Printf("%s: ", message)
+	}
+
+	answer, _ := stdin.ReadString('\n')
+	answer = strings.TrimSpace(answer)
+
+	if answer == "" {
+		return defaultValue
+	}
+	return answer
+}
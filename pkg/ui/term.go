@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// 화면 제어 ANSI 시퀀스
+const (
+	ClearScreen = "\033[H\033[2J"
+	ClearLine   = "\033[2K"
+	HideCursor  = "\033[?25l"
+	ShowCursor  = "\033[?25h"
+	Reverse     = "\033[7m"
+)
+
+// Key 한 번의 키 입력. 특수 키는 Name("up", "down", "enter", "esc", "backspace")으로 구분합니다.
+type Key struct {
+	Rune rune
+	Name string
+}
+
+// RawMode 터미널을 한 글자씩 입력받는 모드(echo 끔)로 전환하고 복원 함수를 반환합니다.
+// Ctrl-C 등 시그널은 그대로 동작합니다.
+func RawMode() (restore func(), err error) {
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, fmt.Errorf("터미널 설정 조회 실패: %v", err)
+	}
+	if _, err := stty("-icanon", "-echo", "min", "1"); err != nil {
+		return nil, fmt.Errorf("터미널 모드 변경 실패: %v", err)
+	}
+	fmt.Print(HideCursor)
+
+	return func() {
+		stty(strings.TrimSpace(saved))
+		fmt.Print(ShowCursor)
+	}, nil
+}
+
+// TermSize 터미널의 행/열 크기를 반환합니다. 조회 실패 시 24x80입니다.
+func TermSize() (rows, cols int) {
+	out, err := stty("size")
+	if err == nil {
+		if _, err := fmt.Sscanf(out, "%d %d", &rows, &cols); err == nil && rows > 0 {
+			return rows, cols
+		}
+	}
+	return 24, 80
+}
+
+// ReadKeys 표준 입력에서 키를 읽어 채널로 전달합니다. RawMode에서 사용합니다.
+func ReadKeys() <-chan Key {
+	keys := make(chan Key)
+	go func() {
+		defer close(keys)
+		buf := make([]byte, 16)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				return
+			}
+			for _, k := range parseKeys(buf[:n]) {
+				keys <- k
+			}
+		}
+	}()
+	return keys
+}
+
+func parseKeys(b []byte) []Key {
+	var keys []Key
+	for len(b) > 0 {
+		switch {
+		case len(b) >= 3 && b[0] == 0x1b && (b[1] == '[' || b[1] == 'O'):
+			switch b[2] {
+			case 'A':
+				keys = append(keys, Key{Name: "up"})
+			case 'B':
+				keys = append(keys, Key{Name: "down"})
+			}
+			b = b[3:]
+		case b[0] == 0x1b:
+			keys = append(keys, Key{Name: "esc"})
+			b = b[1:]
+		case b[0] == '\r' || b[0] == '\n':
+			keys = append(keys, Key{Name: "enter"})
+			b = b[1:]
+		case b[0] == 0x7f || b[0] == 0x08:
+			keys = append(keys, Key{Name: "backspace"})
+			b = b[1:]
+		default:
+			r := []rune(string(b))
+			if len(r) == 0 {
+				return keys
+			}
+			keys = append(keys, Key{Rune: r[0]})
+			b = b[len(string(r[0])):]
+		}
+	}
+	return keys
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}